@@ -0,0 +1,95 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	errNotPrimary = errors.New("eisen: not a primary prime")
+	errDivisible  = errors.New("eisen: prime divides argument")
+	errNotPrime   = errors.New("eisen: not a prime")
+)
+
+// ModLambda returns the image of z in the field with three elements, which
+// is the residue ring ℤ[ω]/(1-ω). Since ω = 1 modulo 1-ω, the image of a+bω
+// is a + b modulo 3, returned as 0, 1, or 2.
+func (z *Stein) ModLambda() int {
+	sum := new(big.Int).Add(&z.l, &z.r)
+	return int(sum.Mod(sum, big.NewInt(3)).Int64())
+}
+
+// isPrimary returns true if z is primary, that is, z = 2 mod 3. If z = a+bω,
+// this means a = 2 and b = 0 mod 3. Every prime not over 3 has exactly one
+// primary associate.
+func (z *Stein) isPrimary() bool {
+	three := big.NewInt(3)
+	a := new(big.Int).Mod(&z.l, three)
+	b := new(big.Int).Mod(&z.r, three)
+	return a.Int64() == 2 && b.Sign() == 0
+}
+
+// primary sets z equal to the primary associate of y, and returns z. It
+// returns nil if y has no primary associate, which happens when 1-ω divides
+// y.
+func (z *Stein) primary(y *Stein) *Stein {
+	a := new(Stein)
+	for _, u := range units {
+		if a.Mul(y, u).isPrimary() {
+			return z.Set(a)
+		}
+	}
+	return nil
+}
+
+// CubicResidue returns the cubic residue symbol (α/π)₃ of alpha modulo the
+// primary prime pi. It is the cube root of unity 1, ω, or Mul(ω, ω) that is
+// congruent to α raised to the power (N - 1)/3, where N is the quadrance of
+// π, and it is one exactly when α is a nonzero cube modulo π. CubicResidue
+// returns an error if pi is not a primary prime or if pi divides alpha.
+func CubicResidue(alpha, pi *Stein) (*Stein, error) {
+	if !pi.isPrimary() || !pi.IsEisensteinPrime() {
+		return nil, errNotPrimary
+	}
+	if pi.Divides(alpha) {
+		return nil, errDivisible
+	}
+	e := pi.Quad()
+	e.Sub(e, big.NewInt(1))
+	e.Quo(e, big.NewInt(3))
+	r := new(Stein).ExpMod(alpha, e, pi)
+	d := new(Stein)
+	for _, u := range omegaPowers {
+		if pi.Divides(d.Sub(r, u)) {
+			return new(Stein).Set(u), nil
+		}
+	}
+	// Since π is prime, α^(N-1) = 1 and r is a cube root of one modulo π.
+	panic("eisen: cubic residue is not a cube root of unity")
+}
+
+// IsCubeMod returns true if z is a cube modulo the prime pi, that is, if
+// Mul(x, Mul(x, x)) - z is divisible by π for some x. Zero is a cube, and so
+// is every value modulo 1-ω, whose residue field has three elements.
+// Otherwise z is a cube exactly when its cubic residue symbol is one.
+// IsCubeMod returns an error if pi is not a prime.
+func (z *Stein) IsCubeMod(pi *Stein) (bool, error) {
+	if !pi.IsEisensteinPrime() {
+		return false, errNotPrime
+	}
+	if pi.Divides(z) {
+		return true, nil
+	}
+	p := new(Stein).primary(pi)
+	if p == nil {
+		return true, nil
+	}
+	chi, err := CubicResidue(z, p)
+	if err != nil {
+		return false, err
+	}
+	return chi.Equals(omegaPowers[0]), nil
+}
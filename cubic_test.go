@@ -0,0 +1,189 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+// primaryPrimes returns the primary primes with quadrance at most bound.
+func primaryPrimes(bound int64) []*Stein {
+	var primes []*Stein
+	for _, z := range SteinsUpToNorm(bound) {
+		if z.isPrimary() && z.IsEisensteinPrime() {
+			primes = append(primes, z)
+		}
+	}
+	return primes
+}
+
+func TestExpMod(t *testing.T) {
+	f := func(x, m *Stein, n uint8) bool {
+		// t.Logf("x = %v, m = %v, n = %v", x, m, n)
+		want := New(big.NewInt(1), big.NewInt(0))
+		for i := uint8(0); i < n; i++ {
+			want.Mul(want, x)
+		}
+		if got := new(Stein).ExpMod(x, big.NewInt(int64(n)), nil); !got.Equals(want) {
+			return false
+		}
+		if m.Quad().Sign() == 0 {
+			return true
+		}
+		got := new(Stein).ExpMod(x, big.NewInt(int64(n)), m)
+		return m.Divides(got.Sub(got, want))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModLambda(t *testing.T) {
+	lambda := New(big.NewInt(1), big.NewInt(-1))
+	if got := lambda.ModLambda(); got != 0 {
+		t.Errorf("%v.ModLambda() = %d, want 0", lambda, got)
+	}
+	for _, u := range units {
+		if u.ModLambda() == 0 {
+			t.Errorf("%v.ModLambda() = 0, want nonzero", u)
+		}
+	}
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		a, b := x.ModLambda(), y.ModLambda()
+		sum := new(Stein).Add(x, y).ModLambda()
+		prod := new(Stein).Mul(x, y).ModLambda()
+		// The image is also the residue of x modulo 1-ω.
+		d := new(Stein).SubInt(x, big.NewInt(int64(a)))
+		return sum == (a+b)%3 && prod == (a*b)%3 && lambda.Divides(d)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPrimary(t *testing.T) {
+	for _, z := range SteinsUpToNorm(100) {
+		p := new(Stein).primary(z)
+		if new(big.Int).Mod(z.Quad(), big.NewInt(3)).Sign() == 0 {
+			if p != nil {
+				t.Errorf("primary(%v) = %v, want nil", z, p)
+			}
+			continue
+		}
+		if p == nil || !p.isPrimary() || !p.IsAssociate(z) {
+			t.Errorf("primary(%v) = %v, want a primary associate", z, p)
+		}
+	}
+}
+
+func TestCubicResidueUnit(t *testing.T) {
+	for _, pi := range primaryPrimes(200) {
+		for _, alpha := range SteinsUpToNorm(20) {
+			chi, err := CubicResidue(alpha, pi)
+			if pi.Divides(alpha) {
+				if err == nil {
+					t.Errorf("CubicResidue(%v, %v) succeeded, want error", alpha, pi)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("CubicResidue(%v, %v) failed: %v", alpha, pi, err)
+				continue
+			}
+			cube := new(Stein).Mul(chi, chi)
+			if !cube.Mul(cube, chi).Equals(New(big.NewInt(1), big.NewInt(0))) {
+				t.Errorf("CubicResidue(%v, %v) = %v, want a cube root of unity", alpha, pi, chi)
+			}
+		}
+	}
+}
+
+func TestCubicResidueMultiplicative(t *testing.T) {
+	primes := primaryPrimes(300)
+	f := func(x, y *Stein, i uint8) bool {
+		// t.Logf("x = %v, y = %v, i = %v", x, y, i)
+		pi := primes[int(i)%len(primes)]
+		if pi.Divides(x) || pi.Divides(y) {
+			return true
+		}
+		a, _ := CubicResidue(x, pi)
+		b, _ := CubicResidue(y, pi)
+		c, _ := CubicResidue(new(Stein).Mul(x, y), pi)
+		cube, _ := CubicResidue(new(Stein).ExpMod(x, big.NewInt(3), nil), pi)
+		return c.Equals(new(Stein).Mul(a, b)) && cube.Equals(New(big.NewInt(1), big.NewInt(0)))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCubicResidueExamples(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	two := New(big.NewInt(2), big.NewInt(0))
+	// Modulo the inert prime 2, the symbol of α is α itself.
+	if got, err := CubicResidue(Omega(), two); err != nil || !got.Equals(Omega()) {
+		t.Errorf("CubicResidue(ω, 2) = %v, %v, want ω", got, err)
+	}
+	// Modulo 2+3ω, over 7, the symbol of ω is ω raised to (7-1)/3 = 2.
+	pi := New(big.NewInt(2), big.NewInt(3))
+	if got, err := CubicResidue(Omega(), pi); err != nil || !got.Equals(UnitPow(2)) {
+		t.Errorf("CubicResidue(ω, %v) = %v, %v, want %v", pi, got, err, UnitPow(2))
+	}
+	// By cubic reciprocity, 2 is a cube modulo a prime p = 1 mod 3 exactly
+	// when p = x² + 27y², so it is a cube modulo 31 but not modulo 7.
+	if got, _ := CubicResidue(two, pi); got.Equals(one) {
+		t.Errorf("CubicResidue(2, %v) = 1, want a non-trivial unit", pi)
+	}
+	for _, pi := range primesOver(big.NewInt(31)) {
+		pi.primary(pi)
+		if got, err := CubicResidue(two, pi); err != nil || !got.Equals(one) {
+			t.Errorf("CubicResidue(2, %v) = %v, %v, want 1", pi, got, err)
+		}
+	}
+	// Invalid moduli.
+	for _, pi := range []*Stein{
+		New(big.NewInt(-2), big.NewInt(0)), // prime, not primary
+		New(big.NewInt(2), big.NewInt(1)),  // 1-ω has no primary associate
+		New(big.NewInt(-4), big.NewInt(0)), // primary, not prime
+	} {
+		if _, err := CubicResidue(one, pi); err == nil {
+			t.Errorf("CubicResidue(1, %v) succeeded, want error", pi)
+		}
+	}
+}
+
+func TestIsCubeModBruteForce(t *testing.T) {
+	for _, pi := range SteinsUpToNorm(80) {
+		if !pi.IsEisensteinPrime() || !new(Stein).Abs(pi).Equals(pi) {
+			continue
+		}
+		residues, _ := pi.Residues()
+		var cubes []*Stein
+		for _, r := range residues {
+			cubes = append(cubes, new(Stein).ExpMod(r, big.NewInt(3), pi))
+		}
+		d := new(Stein)
+		for _, z := range residues {
+			want := false
+			for _, c := range cubes {
+				if pi.Divides(d.Sub(z, c)) {
+					want = true
+					break
+				}
+			}
+			if got, err := z.IsCubeMod(pi); err != nil || got != want {
+				t.Errorf("%v.IsCubeMod(%v) = %v, %v, want %v", z, pi, got, err, want)
+			}
+		}
+	}
+}
+
+func TestIsCubeModNotPrime(t *testing.T) {
+	if _, err := Omega().IsCubeMod(New(big.NewInt(4), big.NewInt(0))); err == nil {
+		t.Error("IsCubeMod modulo 4 succeeded, want error")
+	}
+}
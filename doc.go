@@ -2,4 +2,10 @@
 // Licenced under the MIT License.
 
 // Package eisen implements Eisenstein integer arithmetic.
+//
+// As in math/big, most operations set a receiver z to their result and
+// return z, as in z.Mul(x, y). The receiver may alias any of the operands,
+// so x.Add(x, y) and x.Mul(x, x) work as expected. This holds for Add, Sub,
+// Neg, Conj, Scal, Mul, Quo, and the other methods of this form, including a
+// *big.Int operand that is a component of the receiver.
 package eisen
@@ -0,0 +1,163 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+)
+
+// errMalformed is returned by SetBytes for input not produced by Bytes.
+var errMalformed = errors.New("eisen: malformed Stein encoding")
+
+// appendInt appends the encoding of x to b: a sign byte that is 1 for
+// negative values and 0 otherwise, the length of the absolute value as a
+// four-byte big-endian integer, and the absolute value in big-endian order.
+func appendInt(b []byte, x *big.Int) []byte {
+	if x.Sign() < 0 {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	abs := x.Bytes()
+	var n [4]byte
+	binary.BigEndian.PutUint32(n[:], uint32(len(abs)))
+	b = append(b, n[:]...)
+	return append(b, abs...)
+}
+
+// readInt sets x from the encoding at the start of b, and returns the rest
+// of b.
+func readInt(x *big.Int, b []byte) ([]byte, error) {
+	if len(b) < 5 || b[0] > 1 {
+		return nil, errMalformed
+	}
+	neg := b[0] == 1
+	n := binary.BigEndian.Uint32(b[1:5])
+	b = b[5:]
+	// A leading zero byte is never written by Bytes, so the encoding of
+	// each value is unique.
+	if uint64(len(b)) < uint64(n) || (n > 0 && b[0] == 0) {
+		return nil, errMalformed
+	}
+	x.SetBytes(b[:n])
+	if neg {
+		if x.Sign() == 0 {
+			return nil, errMalformed
+		}
+		x.Neg(x)
+	}
+	return b[n:], nil
+}
+
+// Bytes returns a compact binary encoding of z. Each component is written
+// as a sign byte, a four-byte big-endian length, and the big-endian bytes of
+// its absolute value.
+func (z *Stein) Bytes() []byte {
+	b := appendInt(nil, &z.l)
+	return appendInt(b, &z.r)
+}
+
+// SetBytes sets z to the value encoded in b by Bytes, and returns z. If b is
+// not a valid encoding, SetBytes returns an error and leaves z unchanged.
+func (z *Stein) SetBytes(b []byte) (*Stein, error) {
+	var l, r big.Int
+	b, err := readInt(&l, b)
+	if err != nil {
+		return nil, err
+	}
+	b, err = readInt(&r, b)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 0 {
+		return nil, errMalformed
+	}
+	z.l.Set(&l)
+	z.r.Set(&r)
+	return z, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. The value a+bω is
+// encoded as the array [a, b] of JSON numbers, with no loss of precision.
+func (z *Stein) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]*big.Int{&z.l, &z.r})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts the
+// array form produced by MarshalJSON, and leaves z unchanged on error. As is
+// conventional, the JSON null value is a no-op.
+func (z *Stein) UnmarshalJSON(text []byte) error {
+	if string(text) == "null" {
+		return nil
+	}
+	var v []*big.Int
+	if err := json.Unmarshal(text, &v); err != nil {
+		return err
+	}
+	if len(v) != 2 || v[0] == nil || v[1] == nil {
+		return errMalformed
+	}
+	z.l.Set(v[0])
+	z.r.Set(v[1])
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. A vector is encoded
+// as an array of its entries in the form used by Stein.MarshalJSON, and a
+// nil vector as the empty array.
+func (v SteinVector) MarshalJSON() ([]byte, error) {
+	if v == nil {
+		v = SteinVector{}
+	}
+	return json.Marshal([]*Stein(v))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts the
+// form produced by MarshalJSON, and rejects null entries.
+func (v *SteinVector) UnmarshalJSON(text []byte) error {
+	var w []*Stein
+	if err := json.Unmarshal(text, &w); err != nil {
+		return err
+	}
+	for _, x := range w {
+		if x == nil {
+			return errMalformed
+		}
+	}
+	*v = w
+	return nil
+}
+
+// errRagged is returned for a matrix whose rows have different lengths.
+var errRagged = errors.New("eisen: ragged matrix")
+
+// MarshalJSON implements the json.Marshaler interface. A matrix is encoded
+// as an array of its rows, each in the form used by SteinVector.MarshalJSON.
+// MarshalJSON returns an error if m is ragged.
+func (m SteinMatrix) MarshalJSON() ([]byte, error) {
+	if !m.isRectangular() {
+		return nil, errRagged
+	}
+	if m == nil {
+		m = SteinMatrix{}
+	}
+	return json.Marshal([]SteinVector(m))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts the
+// form produced by MarshalJSON, and rejects ragged input.
+func (m *SteinMatrix) UnmarshalJSON(text []byte) error {
+	var rows []SteinVector
+	if err := json.Unmarshal(text, &rows); err != nil {
+		return err
+	}
+	if !SteinMatrix(rows).isRectangular() {
+		return errRagged
+	}
+	*m = rows
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestBytesRoundTrip(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		y := new(Stein).Neg(x)
+		y.l.Lsh(&y.l, 100)
+		for _, v := range []*Stein{x, y, new(Stein)} {
+			w, err := new(Stein).SetBytes(v.Bytes())
+			if err != nil || !w.Equals(v) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetBytesMalformed(t *testing.T) {
+	valid := New(big.NewInt(-300), big.NewInt(7)).Bytes()
+	var tests = [][]byte{
+		nil,
+		{0, 0, 0},
+		valid[:len(valid)-1],
+		append(append([]byte{}, valid...), 0),
+		{2, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 9, 1, 0, 0, 0, 0, 0},
+		// Magnitudes with leading zero bytes are not canonical.
+		{0, 0, 0, 0, 4, 0, 0, 0, 1, 0, 0, 0, 0, 0},
+		{1, 0, 0, 0, 2, 0, 5, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0},
+	}
+	for _, b := range tests {
+		z := New(big.NewInt(1), big.NewInt(2))
+		if _, err := z.SetBytes(b); err == nil {
+			t.Errorf("SetBytes(%v) succeeded, want error", b)
+		}
+		if !z.Equals(New(big.NewInt(1), big.NewInt(2))) {
+			t.Errorf("SetBytes(%v) modified z to %v", b, z)
+		}
+	}
+}
+
+func TestSteinJSONRoundTrip(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		y := new(Stein).Neg(x)
+		y.l.Lsh(&y.l, 100)
+		for _, v := range []*Stein{x, y, new(Stein)} {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return false
+			}
+			w := new(Stein)
+			if err := json.Unmarshal(b, w); err != nil || !w.Equals(v) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	b, _ := json.Marshal(New(big.NewInt(3), big.NewInt(-2)))
+	if string(b) != "[3,-2]" {
+		t.Errorf("json.Marshal((3-2ω)) = %s, want [3,-2]", b)
+	}
+}
+
+func TestSteinVectorMatrixJSON(t *testing.T) {
+	huge, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	v := SteinVector{
+		New(big.NewInt(1), big.NewInt(-2)),
+		New(huge, big.NewInt(0)),
+		new(Stein),
+		New(big.NewInt(-7), huge),
+		Omega(),
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal(%v) failed: %v", v, err)
+	}
+	var w SteinVector
+	if err := json.Unmarshal(b, &w); err != nil || len(w) != len(v) {
+		t.Fatalf("json.Unmarshal(%s) = %v, %v, want %v", b, w, err, v)
+	}
+	for i := range v {
+		if !w[i].Equals(v[i]) {
+			t.Errorf("vector entry %d = %v, want %v", i, w[i], v[i])
+		}
+	}
+	m := SteinMatrix{v[:3], v[1:4], v[2:5]}
+	if b, err = json.Marshal(m); err != nil {
+		t.Fatalf("json.Marshal(%v) failed: %v", m, err)
+	}
+	var n SteinMatrix
+	if err := json.Unmarshal(b, &n); err != nil || len(n) != 3 {
+		t.Fatalf("json.Unmarshal(%s) = %v, %v, want %v", b, n, err, m)
+	}
+	for i := range m {
+		if len(n[i]) != len(m[i]) {
+			t.Fatalf("matrix row %d = %v, want %v", i, n[i], m[i])
+		}
+		for j := range m[i] {
+			if !n[i][j].Equals(m[i][j]) {
+				t.Errorf("matrix entry (%d, %d) = %v, want %v", i, j, n[i][j], m[i][j])
+			}
+		}
+	}
+}
+
+func TestJSONMalformed(t *testing.T) {
+	for _, s := range []string{`[1]`, `[1,2,3]`, `[1,null]`, `{"a":1}`, `[1.5,2]`} {
+		z := New(big.NewInt(1), big.NewInt(2))
+		if err := json.Unmarshal([]byte(s), z); err == nil {
+			t.Errorf("json.Unmarshal(%s) into a Stein succeeded, want error", s)
+		}
+		if !z.Equals(New(big.NewInt(1), big.NewInt(2))) {
+			t.Errorf("json.Unmarshal(%s) modified z to %v", s, z)
+		}
+	}
+	var v SteinVector
+	if err := json.Unmarshal([]byte(`[[1,2],null]`), &v); err == nil {
+		t.Error("json.Unmarshal of a vector with null succeeded, want error")
+	}
+	var m SteinMatrix
+	if err := json.Unmarshal([]byte(`[[[1,2],[3,4]],[[5,6]]]`), &m); err == nil {
+		t.Error("json.Unmarshal of a ragged matrix succeeded, want error")
+	}
+	ragged := SteinMatrix{{Omega()}, {}}
+	if _, err := json.Marshal(ragged); err == nil {
+		t.Error("json.Marshal of a ragged matrix succeeded, want error")
+	}
+}
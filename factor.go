@@ -0,0 +1,661 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A primePower is a rational prime p raised to the exponent k.
+type primePower struct {
+	p *big.Int
+	k int
+}
+
+// trialLimit bounds the primes removed from an integer by trial division
+// before Pollard's rho method takes over.
+const trialLimit = 1000
+
+// factorInt returns the prime factorization of the positive integer n, in
+// increasing order of the primes.
+func factorInt(n *big.Int) []primePower {
+	pps, _ := factorIntContext(context.Background(), n)
+	return pps
+}
+
+// factorIntContext is like factorInt, but it stops and returns ctx.Err() if
+// ctx is done before the factorization is complete.
+func factorIntContext(ctx context.Context, n *big.Int) ([]primePower, error) {
+	exps := make(map[string]int)
+	primes := make(map[string]*big.Int)
+	add := func(p *big.Int) {
+		key := p.String()
+		if _, ok := primes[key]; !ok {
+			primes[key] = new(big.Int).Set(p)
+		}
+		exps[key]++
+	}
+	m := new(big.Int).Set(n)
+	rem := new(big.Int)
+	for d := int64(2); d < trialLimit; d++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		p := big.NewInt(d)
+		for {
+			q, r := new(big.Int).QuoRem(m, p, rem)
+			if r.Sign() != 0 {
+				break
+			}
+			add(p)
+			m = q
+		}
+	}
+	stack := []*big.Int{m}
+	for len(stack) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		m := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		switch {
+		case m.Cmp(big.NewInt(1)) == 0:
+		case m.ProbablyPrime(20):
+			add(m)
+		default:
+			d, err := rho(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, d, new(big.Int).Quo(m, d))
+		}
+	}
+	pps := make([]primePower, 0, len(primes))
+	for key, p := range primes {
+		pps = append(pps, primePower{p, exps[key]})
+	}
+	sort.Slice(pps, func(i, j int) bool {
+		return pps[i].p.Cmp(pps[j].p) < 0
+	})
+	return pps, nil
+}
+
+// rho returns a non-trivial factor of the odd composite n, using Pollard's
+// rho method with Floyd cycle detection. It returns ctx.Err() if ctx is done
+// first.
+func rho(ctx context.Context, n *big.Int) (*big.Int, error) {
+	one := big.NewInt(1)
+	d, diff := new(big.Int), new(big.Int)
+	for c := int64(1); ; c++ {
+		f := func(x *big.Int) *big.Int {
+			x.Mul(x, x)
+			x.Add(x, big.NewInt(c))
+			return x.Mod(x, n)
+		}
+		x, y := big.NewInt(2), big.NewInt(2)
+		d.SetInt64(1)
+		for i := 0; d.Cmp(one) == 0; i++ {
+			if i%1024 == 0 {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+			}
+			f(x)
+			f(f(y))
+			d.GCD(nil, nil, diff.Abs(diff.Sub(x, y)), n)
+		}
+		if d.Cmp(n) != 0 {
+			return d, nil
+		}
+	}
+}
+
+// RepresentationCount returns the number of Eisenstein integers with
+// quadrance n, that is, the number of ways to write n as a² - ab + b².
+//
+// The count is found from the factorization of n: it is zero if a prime
+// p = 2 mod 3 divides n to an odd power, and otherwise six times the product
+// of k+1 over the primes p = 1 mod 3 dividing n to the power k.
+func RepresentationCount(n *big.Int) int {
+	switch n.Sign() {
+	case -1:
+		return 0
+	case 0:
+		return 1
+	}
+	count := 6
+	three := big.NewInt(3)
+	mod := new(big.Int)
+	for _, pp := range factorInt(n) {
+		switch mod.Mod(pp.p, three).Int64() {
+		case 1:
+			count *= pp.k + 1
+		case 2:
+			if pp.k%2 != 0 {
+				return 0
+			}
+		}
+	}
+	return count
+}
+
+// errZero is returned when an operation is undefined for zero.
+var errZero = errors.New("eisen: undefined for zero")
+
+// A steinPower is an Eisenstein prime p raised to the exponent k.
+type steinPower struct {
+	p *Stein
+	k int
+}
+
+// splitPrime returns the prime a+bω with a > b >= 0 and quadrance p, for a
+// rational prime p = 1 mod 3 such that t - ω is divisible by it, where t is
+// a cube root of unity modulo p found by trial.
+func splitPrime(p *big.Int) *Stein {
+	// The cube root of unity t makes p divide t² + t + 1, which is the
+	// quadrance of t - ω. The gcd of p and t - ω is then a prime over p.
+	t := cubeRootOfUnity(p)
+	return new(Stein).GCD(nil, nil, New(p, big.NewInt(0)), New(t, big.NewInt(-1)))
+}
+
+// cubeRootOfUnity returns a cube root of unity other than one modulo the
+// rational prime p = 1 mod 3, found by trial.
+func cubeRootOfUnity(p *big.Int) *big.Int {
+	// If g is not a cubic residue modulo p, then t = g^((p-1)/3) is a
+	// non-trivial cube root of unity.
+	e := new(big.Int).Sub(p, big.NewInt(1))
+	e.Quo(e, big.NewInt(3))
+	t := new(big.Int)
+	for g := int64(2); ; g++ {
+		t.Exp(big.NewInt(g), e, p)
+		if t.Cmp(big.NewInt(1)) != 0 {
+			return t
+		}
+	}
+}
+
+// errNotDegreeOne is returned when a prime does not have a prime quadrance.
+var errNotDegreeOne = errors.New("eisen: not a prime of degree one")
+
+// ModSplitPrime returns the image of z in the field with p elements, which
+// is the residue ring ℤ[ω]/(π) for a prime π whose quadrance is the rational
+// prime p. The image of a+bω is a + bt modulo p, where t is the cube root of
+// unity modulo p such that π divides t - ω; for π over 3, t is one. The
+// result lies in [0, p). ModSplitPrime returns an error if the quadrance of
+// pi is not a prime.
+func (z *Stein) ModSplitPrime(pi *Stein) (*big.Int, error) {
+	p := pi.Quad()
+	if !p.ProbablyPrime(20) {
+		return nil, errNotDegreeOne
+	}
+	t := big.NewInt(1)
+	if new(big.Int).Mod(p, big.NewInt(3)).Int64() == 1 {
+		// The two non-trivial cube roots of unity are t and t², and π
+		// divides t - ω for exactly one of them.
+		t = cubeRootOfUnity(p)
+		if !pi.Divides(New(t, big.NewInt(-1))) {
+			t.Exp(t, big.NewInt(2), p)
+		}
+	}
+	v := new(big.Int).Mul(&z.r, t)
+	v.Add(v, &z.l)
+	return v.Mod(v, p), nil
+}
+
+// errNotSplit is returned when a rational prime does not split in ℤ[ω].
+var errNotSplit = errors.New("eisen: prime does not split")
+
+// SplitPrime returns the prime π = a+bω with a > b >= 0 whose quadrance is
+// the rational prime p, so that p is the product of π and its conjugate.
+// Such a π exists exactly when p = 1 mod 3. SplitPrime returns an error if p
+// is not a prime, or if p is 3 or p = 2 mod 3.
+func SplitPrime(p *big.Int) (*Stein, error) {
+	if p.Sign() <= 0 || !p.ProbablyPrime(20) {
+		return nil, errNotPrime
+	}
+	if new(big.Int).Mod(p, big.NewInt(3)).Int64() != 1 {
+		return nil, errNotSplit
+	}
+	return splitPrime(p), nil
+}
+
+// EisensteinPrimesOver returns the Eisenstein primes that divide the
+// rational prime p, normalized as in Abs. There is one prime over 3, namely
+// 2+ω, the associate of 1-ω, whose square is an associate of 3. There is
+// one prime p+0ω if p = 2 mod 3, since p stays prime. There are two
+// conjugate primes of quadrance p if p = 1 mod 3, ordered by their
+// components. EisensteinPrimesOver returns an error if p is not a prime.
+func EisensteinPrimesOver(p *big.Int) ([]*Stein, error) {
+	if p.Sign() <= 0 || !p.ProbablyPrime(20) {
+		return nil, errNotPrime
+	}
+	return primesOver(p), nil
+}
+
+// primesOver returns the Eisenstein primes, normalized as in Abs, that divide
+// the rational prime p. Two conjugate primes are returned for p = 1 mod 3,
+// ordered by their components.
+func primesOver(p *big.Int) []*Stein {
+	switch new(big.Int).Mod(p, big.NewInt(3)).Int64() {
+	case 0:
+		return []*Stein{New(big.NewInt(2), big.NewInt(1))}
+	case 2:
+		return []*Stein{New(p, big.NewInt(0))}
+	}
+	pi := splitPrime(p)
+	conj := new(Stein).Conj(pi)
+	conj.Abs(conj)
+	if cmp(conj, pi) < 0 {
+		pi, conj = conj, pi
+	}
+	return []*Stein{pi, conj}
+}
+
+// factorPowers returns the distinct prime factors of z with their exponents,
+// in the order of Factorize.
+func (z *Stein) factorPowers() ([]steinPower, error) {
+	return z.factorPowersContext(context.Background(), 20)
+}
+
+// factorPowersContext is like factorPowers, but it returns ctx.Err() if ctx
+// is done before the quadrance of z is factored. If the quadrance passes
+// ProbablyPrime(reps), z is itself prime and is returned without factoring
+// the quadrance.
+func (z *Stein) factorPowersContext(ctx context.Context, reps int) ([]steinPower, error) {
+	if z.l.Sign() == 0 && z.r.Sign() == 0 {
+		return nil, errZero
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	n := z.Quad()
+	if n.ProbablyPrime(reps) {
+		return []steinPower{{new(Stein).Abs(z), 1}}, nil
+	}
+	pps, err := factorIntContext(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	rest := new(Stein).Set(z)
+	var powers []steinPower
+	for _, pp := range pps {
+		for _, pi := range primesOver(pp.p) {
+			k := 0
+			for pi.Divides(rest) {
+				rest.Quo(rest, pi)
+				k++
+			}
+			if k > 0 {
+				powers = append(powers, steinPower{pi, k})
+			}
+		}
+	}
+	return powers, nil
+}
+
+// Factorize returns the prime factorization of z. The primes are normalized
+// as in Abs and repeated according to their multiplicity, so their product
+// is an associate of z. They are ordered by the rational prime under them,
+// and conjugate primes by their components. The factorization of a unit is
+// empty, and Factorize returns an error if z is zero.
+func (z *Stein) Factorize() ([]*Stein, error) {
+	return z.FactorizeContext(context.Background())
+}
+
+// FactorizeContext is like Factorize, but it checks ctx between the steps of
+// factoring the quadrance of z, and returns ctx.Err() if ctx is done first.
+// This bounds the work spent on values with a large composite quadrance.
+func (z *Stein) FactorizeContext(ctx context.Context) ([]*Stein, error) {
+	return z.factorize(ctx, 20)
+}
+
+// FactorizeReps is like Factorize, but it passes reps to ProbablyPrime when
+// testing whether the quadrance of z is prime. A prime quadrance means that
+// z is prime, so z is then returned, normalized as in Abs, without trial
+// division. Larger values of reps make a wrong answer less likely, as
+// described for big.Int.ProbablyPrime.
+func (z *Stein) FactorizeReps(reps int) ([]*Stein, error) {
+	return z.factorize(context.Background(), reps)
+}
+
+// factorize returns the factorization of z as in Factorize, checking ctx
+// as in FactorizeContext and passing reps to ProbablyPrime.
+func (z *Stein) factorize(ctx context.Context, reps int) ([]*Stein, error) {
+	powers, err := z.factorPowersContext(ctx, reps)
+	if err != nil {
+		return nil, err
+	}
+	factors := []*Stein{}
+	for _, pk := range powers {
+		for i := 0; i < pk.k; i++ {
+			factors = append(factors, new(Stein).Set(pk.p))
+		}
+	}
+	return factors, nil
+}
+
+// FactorString returns the factorization of z as a string such as
+// "(0+1ω) · (2+1ω) · (3+1ω)^2", where the unit comes first and each prime,
+// normalized as in Abs, is followed by its multiplicity if greater than one.
+// The unit is left out when it is one. The string for a unit is the unit
+// itself, and the string for zero is "0".
+func (z *Stein) FactorString() string {
+	powers, err := z.factorPowers()
+	if err != nil {
+		return "0"
+	}
+	prod := New(big.NewInt(1), big.NewInt(0))
+	var parts []string
+	for _, pk := range powers {
+		part := pk.p.String()
+		if pk.k > 1 {
+			part += "^" + strconv.Itoa(pk.k)
+		}
+		parts = append(parts, part)
+		for i := 0; i < pk.k; i++ {
+			prod.Mul(prod, pk.p)
+		}
+	}
+	u, _ := z.UnitFactor(prod)
+	if len(parts) == 0 || !u.Equals(omegaPowers[0]) {
+		parts = append([]string{u.String()}, parts...)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// FactorMap returns the factorization of z as a unit and a map from each
+// prime factor to its multiplicity, so that z is the product of the unit and
+// the primes raised to their multiplicities. The primes are normalized as in
+// Abs and keyed by their String form, which is canonical for them. The
+// factorization of a unit is the unit itself with an empty map, and
+// FactorMap returns an error if z is zero.
+func (z *Stein) FactorMap() (unit *Stein, factors map[string]int, err error) {
+	powers, err := z.factorPowers()
+	if err != nil {
+		return nil, nil, err
+	}
+	prod := New(big.NewInt(1), big.NewInt(0))
+	factors = make(map[string]int, len(powers))
+	for _, pk := range powers {
+		factors[pk.p.String()] = pk.k
+		for i := 0; i < pk.k; i++ {
+			prod.Mul(prod, pk.p)
+		}
+	}
+	unit, _ = z.UnitFactor(prod)
+	return unit, factors, nil
+}
+
+// FactorizeBatch returns the factorizations of xs, as by Factorize, in the
+// same order as xs. The values are factored concurrently by the given number
+// of worker goroutines, or by one if workers is less than one. If any value
+// cannot be factored, the remaining work is cancelled and FactorizeBatch
+// returns the first error encountered.
+func FactorizeBatch(xs []*Stein, workers int) ([][]*Stein, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := make([][]*Stein, len(xs))
+	var (
+		mu       sync.Mutex
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	next := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				factors, err := xs[i].FactorizeContext(ctx)
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mu.Unlock()
+					continue
+				}
+				results[i] = factors
+			}
+		}()
+	}
+	for i := range xs {
+		if ctx.Err() != nil {
+			break
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
+
+// Divisors returns the divisors of z up to associates, each normalized as in
+// Abs, in order of increasing quadrance and then by their components. The
+// only divisor of a unit is one. Divisors returns nil if z is zero.
+func (z *Stein) Divisors() []*Stein {
+	powers, err := z.factorPowers()
+	if err != nil {
+		return nil
+	}
+	divisors := []*Stein{New(big.NewInt(1), big.NewInt(0))}
+	for _, pk := range powers {
+		n := len(divisors)
+		power := new(Stein).Set(pk.p)
+		for i := 1; i <= pk.k; i++ {
+			for _, d := range divisors[:n] {
+				divisors = append(divisors, new(Stein).Mul(d, power))
+			}
+			power.Mul(power, pk.p)
+		}
+	}
+	for _, d := range divisors {
+		d.Abs(d)
+	}
+	sort.Slice(divisors, func(i, j int) bool {
+		if c := divisors[i].Quad().Cmp(divisors[j].Quad()); c != 0 {
+			return c < 0
+		}
+		return cmp(divisors[i], divisors[j]) < 0
+	})
+	return divisors
+}
+
+// SigmaNorm returns the sum of the quadrances of the divisors of z up to
+// associates. It is computed from the factorization of z as the product of
+// 1 + N + ... + Nᵏ over the prime powers πᵏ dividing z, where N is the
+// quadrance of π. SigmaNorm returns nil if z is zero.
+func (z *Stein) SigmaNorm() *big.Int {
+	powers, err := z.factorPowers()
+	if err != nil {
+		return nil
+	}
+	sigma := big.NewInt(1)
+	sum, term := new(big.Int), new(big.Int)
+	for _, pk := range powers {
+		quad := pk.p.Quad()
+		sum.SetInt64(1)
+		term.SetInt64(1)
+		for i := 0; i < pk.k; i++ {
+			sum.Add(sum, term.Mul(term, quad))
+		}
+		sigma.Mul(sigma, sum)
+	}
+	return sigma
+}
+
+// EulerPhi returns the number of units in the residue ring ℤ[ω]/(z). It is
+// computed from the factorization of z as the product of Nᵏ⁻¹(N - 1) over
+// the prime powers πᵏ dividing z, where N is the quadrance of π. EulerPhi
+// returns nil if z is zero.
+func (z *Stein) EulerPhi() *big.Int {
+	powers, err := z.factorPowers()
+	if err != nil {
+		return nil
+	}
+	phi := big.NewInt(1)
+	one := big.NewInt(1)
+	for _, pk := range powers {
+		quad := pk.p.Quad()
+		phi.Mul(phi, new(big.Int).Sub(quad, one))
+		phi.Mul(phi, new(big.Int).Exp(quad, big.NewInt(int64(pk.k-1)), nil))
+	}
+	return phi
+}
+
+// Radical returns the product of the distinct prime factors of z, each
+// normalized as in Abs. The radical of a unit is one. Radical returns nil if
+// z is zero.
+func (z *Stein) Radical() *Stein {
+	powers, err := z.factorPowers()
+	if err != nil {
+		return nil
+	}
+	rad := New(big.NewInt(1), big.NewInt(0))
+	for _, pk := range powers {
+		rad.Mul(rad, pk.p)
+	}
+	return rad
+}
+
+// SquareFreePart returns z divided by the square of the largest d such that
+// Mul(d, d) divides z. It is an associate of the product of the prime factors
+// of z with odd multiplicity, and a unit if z is a perfect square up to
+// associates. SquareFreePart returns nil if z is zero.
+func (z *Stein) SquareFreePart() *Stein {
+	powers, err := z.factorPowers()
+	if err != nil {
+		return nil
+	}
+	d := New(big.NewInt(1), big.NewInt(0))
+	for _, pk := range powers {
+		for i := 0; i < pk.k/2; i++ {
+			d.Mul(d, pk.p)
+		}
+	}
+	return new(Stein).Quo(z, d.Mul(d, d))
+}
+
+// IsPerfectPower reports whether z is equal to a power of exponent at
+// least two. If so, it returns the largest such exponent exp and a base with
+// z = base^exp. The exponent divides the gcd of the multiplicities of the
+// prime factors of z, and the unit of z must also be an exp-th power of a
+// unit. Zero and the units are not reported as perfect powers, so ok is
+// false for them.
+func (z *Stein) IsPerfectPower() (base *Stein, exp int, ok bool) {
+	powers, err := z.factorPowers()
+	if err != nil || len(powers) == 0 {
+		return nil, 0, false
+	}
+	g := 0
+	rest := New(big.NewInt(1), big.NewInt(0))
+	for _, pk := range powers {
+		g = gcdInt(g, pk.k)
+		for i := 0; i < pk.k; i++ {
+			rest.Mul(rest, pk.p)
+		}
+	}
+	u, _ := z.UnitFactor(rest)
+	v := new(Stein)
+	for e := g; e >= 2; e-- {
+		if g%e != 0 {
+			continue
+		}
+		for _, r := range units {
+			if !v.ExpMod(r, big.NewInt(int64(e)), nil).Equals(u) {
+				continue
+			}
+			base = new(Stein).Set(r)
+			for _, pk := range powers {
+				for i := 0; i < pk.k/e; i++ {
+					base.Mul(base, pk.p)
+				}
+			}
+			return base, e, true
+		}
+	}
+	return nil, 0, false
+}
+
+// gcdInt returns the greatest common divisor of the non-negative integers a
+// and b.
+func gcdInt(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// FactorInteger returns the prime factorization of the rational integer n in
+// ℤ[ω], in the same form as Factorize. Each rational prime factor p of n is
+// lifted by the usual rule: 3 is the unit multiple of the square of 1-ω, a
+// prime p = 2 mod 3 stays prime, and a prime p = 1 mod 3 is the product of
+// two conjugate primes. FactorInteger returns an error if n is zero.
+func FactorInteger(n *big.Int) ([]*Stein, error) {
+	if n.Sign() == 0 {
+		return nil, errZero
+	}
+	factors := []*Stein{}
+	for _, pp := range factorInt(new(big.Int).Abs(n)) {
+		k := pp.k
+		if pp.p.Cmp(big.NewInt(3)) == 0 {
+			k *= 2
+		}
+		for _, pi := range primesOver(pp.p) {
+			for i := 0; i < k; i++ {
+				factors = append(factors, new(Stein).Set(pi))
+			}
+		}
+	}
+	return factors, nil
+}
+
+// SolveNorm returns an Eisenstein integer, normalized as in Abs, whose
+// quadrance is n, and true, if there is one. Otherwise it returns nil and
+// false. The solution is built from the factorization of n: a prime
+// p = 1 mod 3 is the quadrance of the prime found by splitPrime, which runs
+// the Euclidean algorithm on p and t - ω as in Cornacchia's method; 3 is the
+// quadrance of 1-ω; and a prime p = 2 mod 3 must divide n to an even power.
+func SolveNorm(n *big.Int) (*Stein, bool) {
+	switch n.Sign() {
+	case -1:
+		return nil, false
+	case 0:
+		return new(Stein), true
+	}
+	z := New(big.NewInt(1), big.NewInt(0))
+	three := big.NewInt(3)
+	mod := new(big.Int)
+	for _, pp := range factorInt(new(big.Int).Set(n)) {
+		pi, k := New(pp.p, big.NewInt(0)), pp.k
+		switch mod.Mod(pp.p, three).Int64() {
+		case 0:
+			pi = New(big.NewInt(2), big.NewInt(1))
+		case 1:
+			pi = splitPrime(pp.p)
+		case 2:
+			if k%2 != 0 {
+				return nil, false
+			}
+			k /= 2
+		}
+		for i := 0; i < k; i++ {
+			z.Mul(z, pi)
+		}
+	}
+	return z.Abs(z), true
+}
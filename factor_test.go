@@ -0,0 +1,788 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+func TestFactorInt(t *testing.T) {
+	m61 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 61), big.NewInt(1))
+	m31 := big.NewInt(1<<31 - 1)
+	n := new(big.Int).Mul(m61, m31)
+	n.Mul(n, big.NewInt(2*2*2*3*997))
+	want := []primePower{
+		{big.NewInt(2), 3},
+		{big.NewInt(3), 1},
+		{big.NewInt(997), 1},
+		{m31, 1},
+		{m61, 1},
+	}
+	got := factorInt(n)
+	if len(got) != len(want) {
+		t.Fatalf("factorInt(%v) = %v, want %v", n, got, want)
+	}
+	for i := range want {
+		if got[i].p.Cmp(want[i].p) != 0 || got[i].k != want[i].k {
+			t.Errorf("factorInt(%v)[%d] = %v, want %v", n, i, got[i], want[i])
+		}
+	}
+}
+
+func TestRepresentationCountBruteForce(t *testing.T) {
+	bound := int64(300)
+	counts := make([]int, bound+1)
+	for _, z := range SteinsUpToNorm(bound) {
+		counts[z.Quad().Int64()]++
+	}
+	for n, want := range counts {
+		if got := RepresentationCount(big.NewInt(int64(n))); got != want {
+			t.Errorf("RepresentationCount(%d) = %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestRepresentationCountLarge(t *testing.T) {
+	// Both Mersenne primes are 1 mod 3, so n = 2² · 7² · m31 · m61 has
+	// 6 · 3 · 2 · 2 representations.
+	m61 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 61), big.NewInt(1))
+	m31 := big.NewInt(1<<31 - 1)
+	n := new(big.Int).Mul(m61, m31)
+	n.Mul(n, big.NewInt(4*49))
+	if got, want := RepresentationCount(n), 72; got != want {
+		t.Errorf("RepresentationCount(%v) = %d, want %d", n, got, want)
+	}
+	n.Mul(n, big.NewInt(2))
+	if got := RepresentationCount(n); got != 0 {
+		t.Errorf("RepresentationCount(%v) = %d, want 0", n, got)
+	}
+}
+
+// isPrimeBruteForce reports whether z is an Eisenstein prime by searching
+// for a divisor of smaller quadrance that is not a unit.
+func isPrimeBruteForce(z *Stein) bool {
+	quad := z.Quad().Int64()
+	if quad <= 1 {
+		return false
+	}
+	for _, d := range SteinsUpToNorm(quad - 1) {
+		if q := d.Quad().Int64(); q > 1 && d.Divides(z) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestIsEisensteinPrimeBruteForce(t *testing.T) {
+	for _, z := range SteinsUpToNorm(150) {
+		want := isPrimeBruteForce(z)
+		if got := z.IsEisensteinPrime(); got != want {
+			t.Errorf("%v.IsEisensteinPrime() = %v, want %v", z, got, want)
+		}
+		if got := z.ProbablyPrime(1); got != want {
+			t.Errorf("%v.ProbablyPrime(1) = %v, want %v", z, got, want)
+		}
+	}
+}
+
+func TestProbablyPrimeLarge(t *testing.T) {
+	// 2⁶⁴ - 59 is a rational prime that is 2 mod 3, so it stays prime, and
+	// a+ω with a = 2⁷⁰ + 2 has the prime quadrance a² - a + 1.
+	p, _ := new(big.Int).SetString("18446744073709551557", 10)
+	a, _ := new(big.Int).SetString("1180591620717411303426", 10)
+	inert := New(p, big.NewInt(0))
+	split := New(a, big.NewInt(1))
+	for _, z := range []*Stein{inert, split} {
+		if !z.ProbablyPrime(20) {
+			t.Errorf("%v.ProbablyPrime(20) = false, want true", z)
+		}
+		if !z.IsEisensteinPrime() {
+			t.Errorf("%v.IsEisensteinPrime() = false, want true", z)
+		}
+	}
+	composite := new(Stein).Mul(inert, split)
+	if composite.ProbablyPrime(20) {
+		t.Errorf("%v.ProbablyPrime(20) = true, want false", composite)
+	}
+}
+
+func TestFactorize(t *testing.T) {
+	f := func(a, b int16) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		z := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		factors, err := z.Factorize()
+		if z.Quad().Sign() == 0 {
+			return err != nil
+		}
+		for _, p := range factors {
+			if !p.IsEisensteinPrime() || !new(Stein).Abs(p).Equals(p) {
+				return false
+			}
+		}
+		return err == nil && Product(factors...).IsAssociate(z)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFactorizeExamples(t *testing.T) {
+	var tests = []struct {
+		z    *Stein
+		want []*Stein
+	}{
+		{New(big.NewInt(1), big.NewInt(0)), nil},
+		{New(big.NewInt(0), big.NewInt(-1)), nil},
+		{New(big.NewInt(3), big.NewInt(0)), []*Stein{
+			New(big.NewInt(2), big.NewInt(1)),
+			New(big.NewInt(2), big.NewInt(1)),
+		}},
+		{New(big.NewInt(14), big.NewInt(0)), []*Stein{
+			New(big.NewInt(2), big.NewInt(0)),
+			New(big.NewInt(3), big.NewInt(1)),
+			New(big.NewInt(3), big.NewInt(2)),
+		}},
+	}
+	for _, test := range tests {
+		got, err := test.z.Factorize()
+		if err != nil || len(got) != len(test.want) {
+			t.Errorf("%v.Factorize() = %v, %v, want %v", test.z, got, err, test.want)
+			continue
+		}
+		for i := range got {
+			if !got[i].Equals(test.want[i]) {
+				t.Errorf("%v.Factorize() = %v, want %v", test.z, got, test.want)
+				break
+			}
+		}
+	}
+	if _, err := new(Stein).Factorize(); err == nil {
+		t.Error("Factorize of zero succeeded, want error")
+	}
+}
+
+func TestDivisors(t *testing.T) {
+	f := func(a, b int16) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		z := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		divisors := z.Divisors()
+		powers, err := z.factorPowers()
+		if err != nil {
+			return divisors == nil
+		}
+		count := 1
+		for _, pk := range powers {
+			count *= pk.k + 1
+		}
+		for i, d := range divisors {
+			if !d.Divides(z) {
+				return false
+			}
+			for _, e := range divisors[:i] {
+				if d.IsAssociate(e) {
+					return false
+				}
+			}
+		}
+		return len(divisors) == count
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDivisorsUnit(t *testing.T) {
+	got := Omega().Divisors()
+	if len(got) != 1 || !got[0].Equals(New(big.NewInt(1), big.NewInt(0))) {
+		t.Errorf("Omega().Divisors() = %v, want [(1+0ω)]", got)
+	}
+}
+
+func TestSigmaNormBruteForce(t *testing.T) {
+	f := func(a, b int16) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		z := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		if z.Quad().Sign() == 0 {
+			return z.SigmaNorm() == nil
+		}
+		sum := new(big.Int)
+		for _, d := range z.Divisors() {
+			sum.Add(sum, d.Quad())
+		}
+		return z.SigmaNorm().Cmp(sum) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSigmaNormMultiplicative(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	f := func(a, b, c, d int8) bool {
+		// t.Logf("a = %v, b = %v, c = %v, d = %v", a, b, c, d)
+		x := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		y := New(big.NewInt(int64(c)), big.NewInt(int64(d)))
+		if !new(Stein).GCD(nil, nil, x, y).Equals(one) {
+			return true
+		}
+		l := new(Stein).Mul(x, y).SigmaNorm()
+		r := new(big.Int).Mul(x.SigmaNorm(), y.SigmaNorm())
+		return l.Cmp(r) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEulerPhiPrime(t *testing.T) {
+	for _, z := range SteinsUpToNorm(200) {
+		if !z.IsEisensteinPrime() {
+			continue
+		}
+		want := new(big.Int).Sub(z.Quad(), big.NewInt(1))
+		if got := z.EulerPhi(); got.Cmp(want) != 0 {
+			t.Errorf("%v.EulerPhi() = %v, want %v", z, got, want)
+		}
+	}
+	if got := new(Stein).EulerPhi(); got != nil {
+		t.Errorf("EulerPhi of zero = %v, want nil", got)
+	}
+}
+
+func TestEulerPhiMultiplicative(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	f := func(a, b, c, d int8) bool {
+		// t.Logf("a = %v, b = %v, c = %v, d = %v", a, b, c, d)
+		x := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		y := New(big.NewInt(int64(c)), big.NewInt(int64(d)))
+		if !new(Stein).GCD(nil, nil, x, y).Equals(one) {
+			return true
+		}
+		l := new(Stein).Mul(x, y).EulerPhi()
+		r := new(big.Int).Mul(x.EulerPhi(), y.EulerPhi())
+		return l.Cmp(r) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRadical(t *testing.T) {
+	f := func(a, b int16) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		z := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		rad := z.Radical()
+		powers, err := z.factorPowers()
+		if err != nil {
+			return rad == nil
+		}
+		if !rad.Divides(z) {
+			return false
+		}
+		for _, pk := range powers {
+			if !pk.p.Divides(rad) || pk.p.Divides(new(Stein).Quo(rad, pk.p)) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRadicalPrimePower(t *testing.T) {
+	for _, p := range SteinsUpToNorm(50) {
+		if !p.IsEisensteinPrime() {
+			continue
+		}
+		power := new(Stein).Set(p)
+		for k := 1; k <= 4; k++ {
+			if got := power.Radical(); !got.IsAssociate(p) {
+				t.Errorf("%v.Radical() = %v, want an associate of %v", power, got, p)
+			}
+			power.Mul(power, p)
+		}
+	}
+}
+
+func TestSquareFreePart(t *testing.T) {
+	f := func(a, b int16) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		z := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		part := z.SquareFreePart()
+		if z.Quad().Sign() == 0 {
+			return part == nil
+		}
+		if !part.Divides(z) || !part.IsAssociate(part.Radical()) {
+			return false
+		}
+		// What remains is a square, so its square-free part is a unit.
+		square := new(Stein).Quo(z, part)
+		return square.SquareFreePart().Quad().Cmp(big.NewInt(1)) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSquareFreePartOfSquare(t *testing.T) {
+	f := func(a, b int8) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		z := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		if z.Quad().Sign() == 0 {
+			return true
+		}
+		sq := new(Stein).Mul(z, z)
+		return sq.SquareFreePart().Quad().Cmp(big.NewInt(1)) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIsPerfectPowerCube(t *testing.T) {
+	three := big.NewInt(3)
+	f := func(a, b int8) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		x := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		if x.Quad().Cmp(big.NewInt(1)) <= 0 {
+			return true
+		}
+		z := new(Stein).ExpMod(x, three, nil)
+		base, exp, ok := z.IsPerfectPower()
+		if !ok || exp%3 != 0 {
+			return false
+		}
+		if !new(Stein).ExpMod(base, big.NewInt(int64(exp)), nil).Equals(z) {
+			return false
+		}
+		root := new(Stein).ExpMod(base, big.NewInt(int64(exp/3)), nil)
+		return root.IsAssociate(x)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIsPerfectPowerSquareFree(t *testing.T) {
+	f := func(a, b int16) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		z := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		if z.Quad().Cmp(big.NewInt(1)) <= 0 {
+			_, _, ok := z.IsPerfectPower()
+			return !ok
+		}
+		rad := new(Stein).Mul(z.Radical(), Omega())
+		base, exp, ok := rad.IsPerfectPower()
+		return base == nil && exp == 0 && !ok
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIsPerfectPowerUnit(t *testing.T) {
+	// 4ω = 2²·ω is a square, since ω = (ω²)², but -4 = 2²·(-1) is not, and
+	// -8 = (-2)³ is a cube.
+	tests := []struct {
+		z   *Stein
+		exp int
+	}{
+		{New(big.NewInt(0), big.NewInt(4)), 2},
+		{New(big.NewInt(-4), big.NewInt(0)), 0},
+		{New(big.NewInt(-8), big.NewInt(0)), 3},
+	}
+	for _, test := range tests {
+		base, exp, ok := test.z.IsPerfectPower()
+		if exp != test.exp || ok != (test.exp != 0) ||
+			(ok && !new(Stein).ExpMod(base, big.NewInt(int64(exp)), nil).Equals(test.z)) {
+			t.Errorf("%v.IsPerfectPower() = %v, %v, %v, want exponent %v", test.z, base, exp, ok, test.exp)
+		}
+	}
+}
+
+func TestFactorInteger(t *testing.T) {
+	f := func(n int32) bool {
+		// t.Logf("n = %v", n)
+		z := New(big.NewInt(int64(n)), big.NewInt(0))
+		factors, err := FactorInteger(big.NewInt(int64(n)))
+		if n == 0 {
+			return err != nil
+		}
+		want, _ := z.Factorize()
+		if err != nil || len(factors) != len(want) {
+			return false
+		}
+		for i, p := range factors {
+			if !p.IsEisensteinPrime() || !p.Equals(want[i]) {
+				return false
+			}
+		}
+		return Product(factors...).IsAssociate(z)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFactorIntegerExamples(t *testing.T) {
+	lambda := New(big.NewInt(2), big.NewInt(1))
+	factors, err := FactorInteger(big.NewInt(3))
+	if err != nil || len(factors) != 2 || !factors[0].Equals(lambda) || !factors[1].Equals(lambda) {
+		t.Errorf("FactorInteger(3) = %v, %v, want [%v %v]", factors, err, lambda, lambda)
+	}
+	pi := New(big.NewInt(3), big.NewInt(1))
+	factors, err = FactorInteger(big.NewInt(-7))
+	if err != nil || len(factors) != 2 || !factors[0].Equals(pi) ||
+		!factors[1].IsAssociate(new(Stein).Conj(pi)) {
+		t.Errorf("FactorInteger(-7) = %v, %v, want %v and its conjugate", factors, err, pi)
+	}
+}
+
+func TestSolveNorm(t *testing.T) {
+	f := func(n uint16) bool {
+		// t.Logf("n = %v", n)
+		m := big.NewInt(int64(n))
+		z, ok := SolveNorm(m)
+		if ok != (RepresentationCount(m) > 0) {
+			return false
+		}
+		return !ok || z.Quad().Cmp(m) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSolveNormExamples(t *testing.T) {
+	for _, n := range []int64{3, 7, 13, 49, 1000003} {
+		m := big.NewInt(n)
+		if z, ok := SolveNorm(m); !ok || z.Quad().Cmp(m) != 0 {
+			t.Errorf("SolveNorm(%d) = %v, %v, want quadrance %d", n, z, ok, n)
+		}
+	}
+	for _, n := range []int64{-3, 2, 5, 11, 1000037} {
+		if z, ok := SolveNorm(big.NewInt(n)); ok {
+			t.Errorf("SolveNorm(%d) = %v, true, want false", n, z)
+		}
+	}
+	// A large prime p = 1 mod 3, beyond the reach of a brute-force search.
+	p, _ := new(big.Int).SetString("1000000000000000000000000000057", 10)
+	if z, ok := SolveNorm(p); !ok || z.Quad().Cmp(p) != 0 {
+		t.Errorf("SolveNorm(%v) = %v, %v, want quadrance %v", p, z, ok, p)
+	}
+}
+
+func TestSplitPrime(t *testing.T) {
+	for _, p := range []int64{7, 13, 19, 31, 1000003} {
+		n := big.NewInt(p)
+		pi, err := SplitPrime(n)
+		if err != nil || pi.Quad().Cmp(n) != 0 || !new(Stein).Abs(pi).Equals(pi) {
+			t.Errorf("SplitPrime(%d) = %v, %v, want a normalized prime of quadrance %d", p, pi, err, p)
+			continue
+		}
+		prod := new(Stein).Mul(pi, new(Stein).Conj(pi))
+		if !prod.Equals(New(n, big.NewInt(0))) {
+			t.Errorf("SplitPrime(%d) = %v, times its conjugate is %v", p, pi, prod)
+		}
+	}
+	for _, p := range []int64{-7, 0, 1, 2, 3, 5, 21, 49} {
+		if pi, err := SplitPrime(big.NewInt(p)); err == nil {
+			t.Errorf("SplitPrime(%d) = %v, want error", p, pi)
+		}
+	}
+}
+
+func TestEisensteinPrimesOver(t *testing.T) {
+	for _, p := range []int64{2, 3, 5, 7, 11, 13, 1000003} {
+		n := big.NewInt(p)
+		primes, err := EisensteinPrimesOver(n)
+		if err != nil {
+			t.Errorf("EisensteinPrimesOver(%d) = %v", p, err)
+			continue
+		}
+		want := 1
+		if p%3 == 1 {
+			want = 2
+		}
+		if len(primes) != want {
+			t.Errorf("EisensteinPrimesOver(%d) = %v, want %d primes", p, primes, want)
+			continue
+		}
+		for _, pi := range primes {
+			if !pi.IsEisensteinPrime() || !new(Stein).Abs(pi).Equals(pi) {
+				t.Errorf("EisensteinPrimesOver(%d) has %v, want normalized primes", p, primes)
+			}
+		}
+		// The quadrance of the product is p², unless p is 3, where the
+		// single prime has quadrance p.
+		wantQuad := new(big.Int).Mul(n, n)
+		if p == 3 {
+			wantQuad.Set(n)
+		}
+		if got := Product(primes...).Quad(); got.Cmp(wantQuad) != 0 {
+			t.Errorf("EisensteinPrimesOver(%d) = %v, with product of quadrance %v, want %v", p, primes, got, wantQuad)
+		}
+	}
+	for _, p := range []int64{-7, 0, 1, 4, 9, 21, 49} {
+		if primes, err := EisensteinPrimesOver(big.NewInt(p)); err == nil {
+			t.Errorf("EisensteinPrimesOver(%d) = %v, want error", p, primes)
+		}
+	}
+}
+
+func TestFactorizeContext(t *testing.T) {
+	// The quadrance is a product of two primes of about 24 bits, which
+	// Pollard's rho method splits quickly.
+	p, _ := SplitPrime(big.NewInt(16777291))
+	q, _ := SplitPrime(big.NewInt(1000003))
+	z := new(Stein).Mul(p, q)
+	factors, err := z.FactorizeContext(context.Background())
+	if err != nil || len(factors) != 2 || !Product(factors...).IsAssociate(z) {
+		t.Errorf("%v.FactorizeContext() = %v, %v, want associates of %v and %v", z, factors, err, p, q)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := z.FactorizeContext(ctx); err != context.Canceled {
+		t.Errorf("FactorizeContext with a cancelled context = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestFactorizeContextDeadline(t *testing.T) {
+	// The quadrance is a product of primes of about 70 and 100 bits, which
+	// would keep Pollard's rho method busy for a long time.
+	p, _ := new(big.Int).SetString("1000000000000000000117", 10)
+	q, _ := new(big.Int).SetString("1000000000000000000000000000057", 10)
+	x, _ := SplitPrime(p)
+	y, _ := SplitPrime(q)
+	z := new(Stein).Mul(x, y)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := z.FactorizeContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("FactorizeContext past the deadline = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("FactorizeContext took %v after a 20ms deadline", d)
+	}
+}
+
+func TestFactorizeReps(t *testing.T) {
+	// The quadrance of a+ω with a = 2⁷⁰ + 2 is a prime of 140 bits, well
+	// beyond the reach of trial division.
+	a, _ := new(big.Int).SetString("1180591620717411303426", 10)
+	z := New(a, big.NewInt(1))
+	w := new(Stein).Mul(z, units[4])
+	want := new(Stein).Abs(z)
+	for _, reps := range []int{0, 1, 20} {
+		factors, err := w.FactorizeReps(reps)
+		if err != nil || len(factors) != 1 || !factors[0].Equals(want) {
+			t.Errorf("%v.FactorizeReps(%v) = %v, %v, want [%v]", w, reps, factors, err, want)
+		}
+	}
+	f := func(a, b int16, reps uint8) bool {
+		// t.Logf("a = %v, b = %v, reps = %v", a, b, reps)
+		z := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		got, err := z.FactorizeReps(int(reps % 32))
+		want, werr := z.Factorize()
+		if werr != nil {
+			return err != nil
+		}
+		if err != nil || len(got) != len(want) {
+			return false
+		}
+		for i := range got {
+			if !got[i].Equals(want[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModSplitPrime(t *testing.T) {
+	var primes []*Stein
+	for _, z := range SteinsUpToNorm(200) {
+		if z.Quad().ProbablyPrime(20) {
+			primes = append(primes, z)
+		}
+	}
+	f := func(x, y *Stein, i uint8) bool {
+		// t.Logf("x = %v, y = %v, i = %v", x, y, i)
+		pi := primes[int(i)%len(primes)]
+		p := pi.Quad()
+		a, err := x.ModSplitPrime(pi)
+		if err != nil {
+			return false
+		}
+		b, _ := y.ModSplitPrime(pi)
+		sum, _ := new(Stein).Add(x, y).ModSplitPrime(pi)
+		prod, _ := new(Stein).Mul(x, y).ModSplitPrime(pi)
+		zero, _ := pi.ModSplitPrime(pi)
+		// The image of x is also its residue modulo π.
+		d := new(Stein).SubInt(x, a)
+		return sum.Cmp(new(big.Int).Mod(new(big.Int).Add(a, b), p)) == 0 &&
+			prod.Cmp(new(big.Int).Mod(new(big.Int).Mul(a, b), p)) == 0 &&
+			zero.Sign() == 0 && pi.Divides(d)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModSplitPrimeNotDegreeOne(t *testing.T) {
+	for _, pi := range []*Stein{
+		New(big.NewInt(2), big.NewInt(0)), // inert, quadrance 4
+		New(big.NewInt(3), big.NewInt(0)), // not prime, quadrance 9
+	} {
+		if v, err := Omega().ModSplitPrime(pi); err == nil {
+			t.Errorf("ModSplitPrime(%v) = %v, want error", pi, v)
+		}
+	}
+}
+
+// parseFactorString returns the product of the factors in a string made by
+// FactorString.
+func parseFactorString(s string) (*Stein, error) {
+	prod := New(big.NewInt(1), big.NewInt(0))
+	if s == "0" {
+		return new(Stein), nil
+	}
+	for _, part := range strings.Split(s, " · ") {
+		var a, b int64
+		k := 1
+		if i := strings.Index(part, "^"); i >= 0 {
+			if _, err := fmt.Sscanf(part[i+1:], "%d", &k); err != nil {
+				return nil, err
+			}
+			part = part[:i]
+		}
+		if _, err := fmt.Sscanf(part, "(%d%dω)", &a, &b); err != nil {
+			return nil, err
+		}
+		for i := 0; i < k; i++ {
+			prod.Mul(prod, New(big.NewInt(a), big.NewInt(b)))
+		}
+	}
+	return prod, nil
+}
+
+func TestFactorString(t *testing.T) {
+	f := func(a, b int16) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		z := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		s := z.FactorString()
+		if s != New(big.NewInt(int64(a)), big.NewInt(int64(b))).FactorString() {
+			return false
+		}
+		prod, err := parseFactorString(s)
+		return err == nil && prod.Equals(z)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFactorStringExamples(t *testing.T) {
+	var tests = []struct {
+		z    *Stein
+		want string
+	}{
+		{new(Stein), "0"},
+		{New(big.NewInt(1), big.NewInt(0)), "(1+0ω)"},
+		{Omega(), "(0+1ω)"},
+		{New(big.NewInt(2), big.NewInt(0)), "(2+0ω)"},
+		{New(big.NewInt(-2), big.NewInt(0)), "(-1+0ω) · (2+0ω)"},
+		{New(big.NewInt(14), big.NewInt(0)), "(0-1ω) · (2+0ω) · (3+1ω) · (3+2ω)"},
+		{New(big.NewInt(12), big.NewInt(0)), "(0-1ω) · (2+0ω)^2 · (2+1ω)^2"},
+		{New(big.NewInt(2), big.NewInt(1)), "(2+1ω)"},
+	}
+	for _, test := range tests {
+		if got := test.z.FactorString(); got != test.want {
+			t.Errorf("%v.FactorString() = %q, want %q", test.z, got, test.want)
+		}
+	}
+}
+
+func TestFactorMap(t *testing.T) {
+	f := func(a, b int16) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		z := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		unit, factors, err := z.FactorMap()
+		if z.Quad().Sign() == 0 {
+			return err != nil
+		}
+		if err != nil || unit.Quad().Cmp(big.NewInt(1)) != 0 {
+			return false
+		}
+		prod := new(Stein).Set(unit)
+		for key, k := range factors {
+			var c, d int64
+			if _, err := fmt.Sscanf(key, "(%d%dω)", &c, &d); err != nil {
+				return false
+			}
+			p := New(big.NewInt(c), big.NewInt(d))
+			if !p.IsEisensteinPrime() || !new(Stein).Abs(p).Equals(p) || k < 1 {
+				return false
+			}
+			prod.Mul(prod, new(Stein).ExpMod(p, big.NewInt(int64(k)), nil))
+		}
+		return prod.Equals(z)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFactorizeBatch(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	xs := make([]*Stein, 100)
+	wants := make([][]*Stein, len(xs))
+	for i := range xs {
+		xs[i] = NewRandom(rnd, 24)
+		if xs[i].Quad().Sign() == 0 {
+			xs[i].SetInt64s(1, 0)
+		}
+		wants[i], _ = xs[i].Factorize()
+	}
+	for _, workers := range []int{0, 1, 4, 64} {
+		got, err := FactorizeBatch(xs, workers)
+		if err != nil || len(got) != len(xs) {
+			t.Fatalf("FactorizeBatch(xs, %d) = %v, %v", workers, got, err)
+		}
+		for i, want := range wants {
+			if len(got[i]) != len(want) {
+				t.Errorf("FactorizeBatch(xs, %d)[%d] = %v, want %v", workers, i, got[i], want)
+				continue
+			}
+			for j := range want {
+				if !got[i][j].Equals(want[j]) {
+					t.Errorf("FactorizeBatch(xs, %d)[%d] = %v, want %v", workers, i, got[i], want)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestFactorizeBatchError(t *testing.T) {
+	xs := []*Stein{Omega(), New(big.NewInt(14), big.NewInt(0)), new(Stein), New(big.NewInt(3), big.NewInt(0))}
+	if got, err := FactorizeBatch(xs, 8); err != errZero || got != nil {
+		t.Errorf("FactorizeBatch with a zero = %v, %v, want nil, %v", got, err, errZero)
+	}
+	if got, err := FactorizeBatch(nil, 8); err != nil || len(got) != 0 {
+		t.Errorf("FactorizeBatch(nil, 8) = %v, %v, want []", got, err)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import "math/big"
+
+// GCD sets z to the greatest common divisor of a and b and returns z. The
+// divisor is normalized as in Abs, so it is unique. If x or y are not nil,
+// GCD sets their value such that z = a*x + b*y.
+//
+// The greatest common divisor of zero and zero is zero.
+func (z *Stein) GCD(x, y, a, b *Stein) *Stein {
+	r0, r1 := new(Stein).Set(a), new(Stein).Set(b)
+	s0, s1 := New(big.NewInt(1), big.NewInt(0)), new(Stein)
+	t0, t1 := new(Stein), New(big.NewInt(1), big.NewInt(0))
+	q, r, temp := new(Stein), new(Stein), new(Stein)
+	for r1.l.Sign() != 0 || r1.r.Sign() != 0 {
+		q.QuoRem(r0, r1, r)
+		r0, r1, r = r1, r, r0
+		s0.Sub(s0, temp.Mul(q, s1))
+		s0, s1 = s1, s0
+		t0.Sub(t0, temp.Mul(q, t1))
+		t0, t1 = t1, t0
+	}
+	_, k := z.sextant(r0)
+	if x != nil {
+		x.rotate(s0, k)
+	}
+	if y != nil {
+		y.rotate(t0, k)
+	}
+	return z
+}
+
+// GCDSlice returns the greatest common divisor of the values in xs, in the
+// same normal form as GCD. It returns zero if xs is empty or if every value
+// in xs is zero.
+func GCDSlice(xs []*Stein) *Stein {
+	z := new(Stein)
+	for _, x := range xs {
+		z.GCD(nil, nil, z, x)
+	}
+	return z
+}
+
+// ShortestIn returns a nonzero element of least quadrance in the ideal
+// generated by z and w, normalized as in Abs. Since ℤ[ω] is a principal ideal
+// domain, the ideal is generated by the greatest common divisor of z and w,
+// which divides every element of it and so is the shortest. ShortestIn
+// returns zero if z and w are both zero.
+func (z *Stein) ShortestIn(w *Stein) *Stein {
+	return new(Stein).GCD(nil, nil, z, w)
+}
+
+// GCDCofactors sets z to the greatest common divisor g of x and y, as in GCD,
+// and returns g together with the cofactors x/g and y/g, which have no common
+// factor other than a unit. If x and y are both zero, g is zero and both
+// cofactors are one.
+func (z *Stein) GCDCofactors(x, y *Stein) (g, cx, cy *Stein) {
+	// The cofactors are found before z is set, since z may alias x or y.
+	d := new(Stein).GCD(nil, nil, x, y)
+	if d.l.Sign() == 0 && d.r.Sign() == 0 {
+		cx, cy = New(big.NewInt(1), big.NewInt(0)), New(big.NewInt(1), big.NewInt(0))
+	} else {
+		cx, cy = new(Stein).Quo(x, d), new(Stein).Quo(y, d)
+	}
+	return z.Set(d), cx, cy
+}
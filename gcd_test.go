@@ -0,0 +1,261 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestQuoRemIdentity(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		q, r := new(Stein).QuoRem(x, y, new(Stein))
+		l := new(Stein).Mul(q, y)
+		l.Add(l, r)
+		bound := new(big.Int).Mul(r.Quad(), big.NewInt(3))
+		return l.Equals(x) && bound.Cmp(y.Quad()) <= 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestQuoError(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		if y.Quad().Sign() == 0 {
+			return true
+		}
+		q, e := new(Stein).QuoError(x, y)
+		if !q.Equals(new(Stein).Quo(new(Stein).Sub(x, new(Stein).Rem(x, y)), y)) {
+			return false
+		}
+		_, exact := new(Stein).QuoError(new(Stein).Mul(x, y), y)
+		return e.Cmp(y.Quad()) < 0 && (e.Sign() == 0) == y.Divides(x) && exact.Sign() == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDivModIdentity(t *testing.T) {
+	f := func(x, s *Stein, a, b int8) bool {
+		// t.Logf("x = %v, s = %v, a = %v, b = %v", x, s, a, b)
+		y := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		if y.Quad().Sign() == 0 {
+			return true
+		}
+		q, m := new(Stein).DivMod(x, y, new(Stein))
+		l := new(Stein).Mul(q, y)
+		if !l.Add(l, m).Equals(x) {
+			return false
+		}
+		// Every value in the residue class of x has the same remainder.
+		w := new(Stein).Mul(s, y)
+		_, n := new(Stein).DivMod(w.Add(w, x), y, new(Stein))
+		if !n.Equals(m) {
+			return false
+		}
+		c := new(big.Int).GCD(nil, nil, big.NewInt(int64(a)), big.NewInt(int64(b)))
+		c.Abs(c)
+		u, v := m.Integers()
+		bound := new(big.Int).Quo(y.Quad(), c)
+		return u.Sign() >= 0 && u.Cmp(bound) < 0 && v.Sign() >= 0 && v.Cmp(c) < 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDivModAlias(t *testing.T) {
+	x, y := New(big.NewInt(17), big.NewInt(-4)), New(big.NewInt(3), big.NewInt(1))
+	q, m := new(Stein).DivMod(x, y, new(Stein))
+	z := new(Stein).Set(x)
+	z.DivMod(z, y, y)
+	if !z.Equals(q) || !y.Equals(m) {
+		t.Errorf("DivMod with aliased arguments = %v, %v, want %v, %v", z, y, q, m)
+	}
+}
+
+func TestDivides(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		p := new(Stein).Mul(x, y)
+		r := new(Stein).Rem(x, y)
+		return y.Divides(p) && x.Divides(p) &&
+			y.Divides(x) == (r.l.Sign() == 0 && r.r.Sign() == 0)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExactQuo(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		p := new(Stein).Mul(x, y)
+		if y.Quad().Sign() == 0 {
+			_, err := new(Stein).ExactQuo(p, y)
+			return err != nil
+		}
+		q, err := new(Stein).ExactQuo(p, y)
+		if err != nil || !q.Equals(x) {
+			return false
+		}
+		// Adding one to the product leaves a remainder unless y is a unit.
+		z := New(big.NewInt(7), big.NewInt(3))
+		p.Add(p, New(big.NewInt(1), big.NewInt(0)))
+		_, err = z.ExactQuo(p, y)
+		if y.Quad().Cmp(big.NewInt(1)) == 0 {
+			return err == nil
+		}
+		return err != nil && z.Equals(New(big.NewInt(7), big.NewInt(3)))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGCDBezout(t *testing.T) {
+	f := func(a, b *Stein) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		x, y := new(Stein), new(Stein)
+		g := new(Stein).GCD(x, y, a, b)
+		l := new(Stein).Mul(a, x)
+		l.Add(l, new(Stein).Mul(b, y))
+		return l.Equals(g) && new(Stein).Abs(g).Equals(g) && g.Divides(a) && g.Divides(b)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGCDSliceDivides(t *testing.T) {
+	f := func(xs []*Stein) bool {
+		// t.Logf("xs = %v", xs)
+		g := GCDSlice(xs)
+		for _, x := range xs {
+			if !g.Divides(x) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGCDSliceScal(t *testing.T) {
+	f := func(xs []*Stein, c *Stein) bool {
+		// t.Logf("xs = %v, c = %v", xs, c)
+		ys := make([]*Stein, len(xs))
+		for i, x := range xs {
+			ys[i] = new(Stein).Mul(x, c)
+		}
+		l := GCDSlice(ys)
+		r := new(Stein).Mul(GCDSlice(xs), c)
+		return l.IsAssociate(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGCDSliceZeros(t *testing.T) {
+	zero := new(Stein)
+	x := New(big.NewInt(6), big.NewInt(3))
+	y := New(big.NewInt(-4), big.NewInt(-2))
+	if got := GCDSlice(nil); !got.Equals(zero) {
+		t.Errorf("GCDSlice(nil) = %v, want %v", got, zero)
+	}
+	if got := GCDSlice([]*Stein{zero, zero}); !got.Equals(zero) {
+		t.Errorf("GCDSlice(0, 0) = %v, want %v", got, zero)
+	}
+	// The values are 3(2+ω) and -2(2+ω), and 2+ω lies in the first sextant.
+	want := New(big.NewInt(2), big.NewInt(1))
+	if got := GCDSlice([]*Stein{zero, x, zero, y}); !got.Equals(want) {
+		t.Errorf("GCDSlice(0, %v, 0, %v) = %v, want %v", x, y, got, want)
+	}
+}
+
+func TestShortestIn(t *testing.T) {
+	f := func(a, b, c, d int8) bool {
+		// t.Logf("a = %v, b = %v, c = %v, d = %v", a, b, c, d)
+		z := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		w := New(big.NewInt(int64(c)), big.NewInt(int64(d)))
+		g := z.ShortestIn(w)
+		if !g.Divides(z) || !g.Divides(w) {
+			return false
+		}
+		// g is a combination of z and w.
+		x, y := new(Stein), new(Stein)
+		new(Stein).GCD(x, y, z, w)
+		comb := new(Stein).Mul(z, x)
+		if !comb.Add(comb, y.Mul(w, y)).Equals(g) {
+			return false
+		}
+		// No small nonzero combination is shorter.
+		quad := g.Quad()
+		for _, u := range SteinsUpToNorm(3) {
+			for _, v := range SteinsUpToNorm(3) {
+				e := new(Stein).Mul(z, u)
+				e.Add(e, new(Stein).Mul(w, v))
+				if q := e.Quad(); q.Sign() != 0 && q.Cmp(quad) < 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGCDCofactors(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	f := func(x, y, s *Stein) bool {
+		// t.Logf("x = %v, y = %v, s = %v", x, y, s)
+		// Scaling by s gives the inputs a common factor.
+		a, b := new(Stein).Mul(x, s), new(Stein).Mul(y, s)
+		z := new(Stein)
+		g, cx, cy := z.GCDCofactors(a, b)
+		return g == z && new(Stein).Mul(g, cx).Equals(a) && new(Stein).Mul(g, cy).Equals(b) &&
+			new(Stein).GCD(nil, nil, cx, cy).Equals(one)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	g, cx, cy := new(Stein).GCDCofactors(new(Stein), new(Stein))
+	if !g.Equals(new(Stein)) || !cx.Equals(one) || !cy.Equals(one) {
+		t.Errorf("GCDCofactors(0, 0) = %v, %v, %v, want 0, 1, 1", g, cx, cy)
+	}
+}
+
+func TestGCDCofactorsAlias(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		want, wantX, wantY := new(Stein).GCDCofactors(x, y)
+		a, b := new(Stein).Set(x), new(Stein).Set(y)
+		g, cx, cy := a.GCDCofactors(a, b)
+		if g != a || !g.Equals(want) || !cx.Equals(wantX) || !cy.Equals(wantY) {
+			return false
+		}
+		a, b = new(Stein).Set(x), new(Stein).Set(y)
+		g, cx, cy = b.GCDCofactors(a, b)
+		return g == b && g.Equals(want) && cx.Equals(wantX) && cy.Equals(wantY)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	x := New(big.NewInt(-718533573), big.NewInt(-221139384))
+	y := New(big.NewInt(-1756), big.NewInt(-3410))
+	_, want, _ := new(Stein).GCDCofactors(x, y)
+	if _, cx, _ := x.GCDCofactors(x, y); !cx.Equals(want) {
+		t.Errorf("x.GCDCofactors(x, y) gives the cofactor %v, want %v", cx, want)
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math"
+	"math/big"
+	"sort"
+)
+
+// Dist returns the squared Euclidean distance between z and y, that is, the
+// quadrance of z - y.
+func (z *Stein) Dist(y *Stein) *big.Int {
+	return new(Stein).Sub(z, y).Quad()
+}
+
+// Dot returns the Euclidean dot product of z and y as vectors in the plane,
+// which is the real part of Mul(Conj(z), y). If z = a+bω and y = c+dω, then
+// the dot product is ac + bd - (ad + bc)/2. It is a half-integer in general,
+// so it is returned as a big.Rat; Dot(z, z) is the quadrance of z.
+func (z *Stein) Dot(y *Stein) *big.Rat {
+	ac := new(big.Int).Mul(&z.l, &y.l)
+	bd := new(big.Int).Mul(&z.r, &y.r)
+	ad := new(big.Int).Mul(&z.l, &y.r)
+	bc := new(big.Int).Mul(&z.r, &y.l)
+	num := new(big.Int).Add(ac, bd)
+	num.Lsh(num, 1)
+	num.Sub(num, ad)
+	num.Sub(num, bc)
+	return new(big.Rat).SetFrac(num, big.NewInt(2))
+}
+
+// Arg returns the argument of z as a complex number, in radians and in the
+// range (-π, π], rounded to prec bits of precision. The argument of zero is
+// undefined, and a big.Float cannot hold a NaN, so Arg returns nil if z is
+// zero.
+func (z *Stein) Arg(prec uint) *big.Float {
+	if z.l.Sign() == 0 && z.r.Sign() == 0 {
+		return nil
+	}
+	wp := prec + 64
+	x, y := z.rect(wp)
+	if y.Sign() == 0 {
+		if x.Sign() < 0 {
+			return pi(wp).SetPrec(prec)
+		}
+		return new(big.Float).SetPrec(prec)
+	}
+	// The half-angle formula tan(θ/2) = y/(r+x) = (r-x)/y is used in the
+	// form that avoids cancellation.
+	r := new(big.Float).SetPrec(wp).SetInt(z.Quad())
+	r.Sqrt(r)
+	t := new(big.Float).SetPrec(wp)
+	if x.Sign() >= 0 {
+		t.Quo(y, t.Add(r, x))
+	} else {
+		t.Quo(t.Sub(r, x), y)
+	}
+	t = atan(t, wp)
+	return t.SetMantExp(t, 1).SetPrec(prec)
+}
+
+// Modulus returns the Euclidean length of z as a complex number, which is
+// the square root of the quadrance of z, rounded to prec bits of precision.
+func (z *Stein) Modulus(prec uint) *big.Float {
+	r := new(big.Float).SetPrec(prec + 64).SetInt(z.Quad())
+	return r.Sqrt(r).SetPrec(prec)
+}
+
+// FromPolar returns a pointer to the Eisenstein integer nearest to the
+// complex number with modulus r and argument theta, in radians, rounded as
+// in RoundToStein. The point is computed in float64 arithmetic, so a point
+// very close to a tie may round either way. FromPolar returns nil if the
+// point is not finite.
+func FromPolar(r, theta float64) *Stein {
+	sin, cos := math.Sincos(theta)
+	x, y := r*cos, r*sin
+	// In the basis 1, ω, the point x+yi is a+bω with b = 2y/√3 and
+	// a = x + b/2.
+	b := 2 * y / math.Sqrt(3)
+	a := x + b/2
+	ra, rb := new(big.Rat), new(big.Rat)
+	if ra.SetFloat64(a) == nil || rb.SetFloat64(b) == nil {
+		return nil
+	}
+	return RoundToStein(ra, rb)
+}
+
+// Cross returns the oriented area of the parallelogram spanned by a and b,
+// in units of the area √3/2 of the cell spanned by 1 and ω. If a = p+qω and
+// b = r+sω, it is the integer ps - qr, which is also the ω component of
+// Mul(Conj(a), b). It is positive if b lies counterclockwise from a, negative
+// if clockwise, and zero if a and b are parallel. Twice the signed area of
+// the triangle with vertices 0, a, and b is the same number in the same
+// units.
+func Cross(a, b *Stein) *big.Int {
+	c := new(big.Int).Mul(&a.l, &b.r)
+	return c.Sub(c, new(big.Int).Mul(&a.r, &b.l))
+}
+
+// Collinear returns true if the points a, b, and c lie on a common line,
+// that is, if b - a and c - a are parallel. The test is exact.
+func Collinear(a, b, c *Stein) bool {
+	u := new(Stein).Sub(b, a)
+	v := new(Stein).Sub(c, a)
+	return Cross(u, v).Sign() == 0
+}
+
+// ConvexHull returns the vertices of the convex hull of points in
+// counterclockwise order, starting from the least point by its components.
+// Only corners are returned: points in the interior of an edge, and repeated
+// points, are left out. The hull of a single point is that point, and the
+// hull of collinear points is the two extreme points. Orientation is decided
+// exactly with Cross.
+func ConvexHull(points []*Stein) []*Stein {
+	ps := make([]*Stein, len(points))
+	copy(ps, points)
+	sort.Slice(ps, func(i, j int) bool {
+		return cmp(ps[i], ps[j]) < 0
+	})
+	n := 0
+	for _, p := range ps {
+		if n == 0 || !ps[n-1].Equals(p) {
+			ps[n] = p
+			n++
+		}
+	}
+	ps = ps[:n]
+	if n < 3 {
+		hull := make([]*Stein, n)
+		for i, p := range ps {
+			hull[i] = new(Stein).Set(p)
+		}
+		return hull
+	}
+	// Andrew's monotone chain, in the coordinates a, b of a+bω. The map to
+	// the plane is linear with positive determinant, so it keeps both the
+	// convexity and the orientation given by Cross.
+	u, v := new(Stein), new(Stein)
+	turnsLeft := func(o, a, b *Stein) bool {
+		return Cross(u.Sub(a, o), v.Sub(b, o)).Sign() > 0
+	}
+	hull := make([]*Stein, 0, 2*n)
+	for _, p := range ps {
+		for len(hull) >= 2 && !turnsLeft(hull[len(hull)-2], hull[len(hull)-1], p) {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := n - 2; i >= 0; i-- {
+		p := ps[i]
+		for len(hull) >= lower && !turnsLeft(hull[len(hull)-2], hull[len(hull)-1], p) {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	// The last point is the first one again.
+	hull = hull[:len(hull)-1]
+	for i, p := range hull {
+		hull[i] = new(Stein).Set(p)
+	}
+	return hull
+}
+
+// LatticePointsInTriangle returns the number of Eisenstein integers strictly
+// inside the triangle with vertices a, b, and c. It uses Pick's theorem in
+// the coordinates of the basis 1, ω, where twice the area of the triangle is
+// the absolute value of Cross(b - a, c - a), and an edge from p to q holds
+// one boundary point for each step of gcd(x, y), for q - p = x+yω. A
+// degenerate triangle has no points inside.
+func LatticePointsInTriangle(a, b, c *Stein) *big.Int {
+	area := Cross(new(Stein).Sub(b, a), new(Stein).Sub(c, a))
+	area.Abs(area)
+	if area.Sign() == 0 {
+		return new(big.Int)
+	}
+	// Pick's theorem A = I + B/2 - 1 gives 2I = 2A - B + 2.
+	boundary := new(big.Int)
+	e := new(Stein)
+	for _, edge := range [3][2]*Stein{{a, b}, {b, c}, {c, a}} {
+		boundary.Add(boundary, e.Sub(edge[1], edge[0]).Content())
+	}
+	inside := area.Sub(area, boundary)
+	inside.Add(inside, big.NewInt(2))
+	return inside.Rsh(inside, 1)
+}
+
+// NearestNeighbor returns the point of points nearest to query, together
+// with its squared distance Dist to query. Among points at the same
+// distance, the least by its components is returned. The search is a linear
+// scan. NearestNeighbor returns nil and nil if points is empty.
+func NearestNeighbor(query *Stein, points []*Stein) (*Stein, *big.Int) {
+	var best *Stein
+	var dist *big.Int
+	for _, p := range points {
+		d := p.Dist(query)
+		if best == nil || d.Cmp(dist) < 0 || (d.Cmp(dist) == 0 && cmp(p, best) < 0) {
+			best, dist = p, d
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+	return new(Stein).Set(best), dist
+}
+
+// rect returns the rectangular coordinates a - b/2 and b√3/2 of z = a+bω,
+// with prec bits of precision.
+func (z *Stein) rect(prec uint) (x, y *big.Float) {
+	half := new(big.Float).SetPrec(prec).SetInt(&z.r)
+	half.SetMantExp(half, -1)
+	x = new(big.Float).SetPrec(prec).SetInt(&z.l)
+	x.Sub(x, half)
+	y = new(big.Float).SetPrec(prec).SetInt64(3)
+	y.Sqrt(y)
+	y.Mul(y, half)
+	return
+}
+
+// pi returns π with prec bits of precision.
+func pi(prec uint) *big.Float {
+	p := atan(new(big.Float).SetPrec(prec).SetInt64(1), prec)
+	return p.SetMantExp(p, 2)
+}
+
+// atan returns the arctangent of t with prec bits of precision.
+func atan(t *big.Float, prec uint) *big.Float {
+	// Each step of atan(t) = 2 atan(t / (1 + √(1 + t²))) halves the angle,
+	// until the Taylor series converges quickly.
+	x := new(big.Float).SetPrec(prec).Set(t)
+	one := new(big.Float).SetPrec(prec).SetInt64(1)
+	limit := new(big.Float).SetMantExp(one, -16)
+	s := new(big.Float).SetPrec(prec)
+	k := 0
+	for new(big.Float).Abs(x).Cmp(limit) > 0 {
+		s.Mul(x, x)
+		s.Add(s, one)
+		s.Sqrt(s)
+		s.Add(s, one)
+		x.Quo(x, s)
+		k++
+	}
+	sum := new(big.Float).SetPrec(prec).Set(x)
+	term := new(big.Float).SetPrec(prec).Set(x)
+	x2 := new(big.Float).SetPrec(prec).Mul(x, x)
+	next := new(big.Float).SetPrec(prec)
+	for n := int64(3); ; n += 2 {
+		term.Mul(term, x2)
+		term.Neg(term)
+		next.Quo(term, s.SetInt64(n))
+		next.Add(sum, next)
+		if next.Cmp(sum) == 0 {
+			break
+		}
+		sum.Set(next)
+	}
+	return sum.SetMantExp(sum, k)
+}
@@ -0,0 +1,438 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestDistSymmetric(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		return x.Dist(y).Cmp(y.Dist(x)) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDistZero(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		return x.Dist(x).Sign() == 0 && (x.Dist(y).Sign() == 0) == x.Equals(y)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDistQuadSub(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		return x.Dist(y).Cmp(new(Stein).Sub(x, y).Quad()) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDotQuad(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		return x.Dot(x).Cmp(new(big.Rat).SetInt(x.Quad())) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDotSymmetric(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		return x.Dot(y).Cmp(y.Dot(x)) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDotBilinear(t *testing.T) {
+	f := func(x, y, z *Stein, n int64) bool {
+		// t.Logf("x = %v, y = %v, z = %v, n = %v", x, y, z, n)
+		a := big.NewInt(n)
+		l := new(Stein).Scal(x, a).Dot(y)
+		r := new(big.Rat).Mul(new(big.Rat).SetInt(a), x.Dot(y))
+		sum := new(big.Rat).Add(x.Dot(z), y.Dot(z))
+		return l.Cmp(r) == 0 && new(Stein).Add(x, y).Dot(z).Cmp(sum) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDotHalfInteger(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	if got, want := one.Dot(Omega()), big.NewRat(-1, 2); got.Cmp(want) != 0 {
+		t.Errorf("Dot(1, ω) = %v, want %v", got, want)
+	}
+}
+
+func TestArg(t *testing.T) {
+	var tests = []struct {
+		z    *Stein
+		want float64
+	}{
+		{New(big.NewInt(5), big.NewInt(0)), 0},
+		{Omega(), 2 * math.Pi / 3},
+		{New(big.NewInt(1), big.NewInt(1)), math.Pi / 3},
+		{New(big.NewInt(-3), big.NewInt(0)), math.Pi},
+		{New(big.NewInt(-1), big.NewInt(-1)), -2 * math.Pi / 3},
+		{New(big.NewInt(1), big.NewInt(2)), math.Pi / 2},
+		{New(big.NewInt(-1000000), big.NewInt(-1)), math.Atan2(-math.Sqrt(3)/2, -999999.5)},
+	}
+	for _, test := range tests {
+		got, _ := test.z.Arg(53).Float64()
+		if math.Abs(got-test.want) > 1e-15*math.Max(1, math.Abs(test.want)) {
+			t.Errorf("%v.Arg(53) = %v, want %v", test.z, got, test.want)
+		}
+	}
+	if got := new(Stein).Arg(53); got != nil {
+		t.Errorf("Arg of zero = %v, want nil", got)
+	}
+}
+
+func TestArgPrecision(t *testing.T) {
+	want, _, _ := big.ParseFloat("3.14159265358979323846264338327950288419716939937510582097494459", 10, 200, big.ToNearestEven)
+	got := New(big.NewInt(-1), big.NewInt(0)).Arg(200)
+	diff := new(big.Float).Sub(got, want)
+	if diff.Abs(diff).Cmp(new(big.Float).SetMantExp(big.NewFloat(1), -195)) > 0 {
+		t.Errorf("Arg(-1) = %v, want %v", got, want)
+	}
+	third := New(big.NewInt(1), big.NewInt(1)).Arg(200)
+	third.Mul(third, big.NewFloat(3))
+	diff.Sub(third, want)
+	if diff.Abs(diff).Cmp(new(big.Float).SetMantExp(big.NewFloat(1), -195)) > 0 {
+		t.Errorf("3 Arg(1+ω) = %v, want %v", third, want)
+	}
+}
+
+func TestModulus(t *testing.T) {
+	if got, _ := Omega().Modulus(53).Float64(); got != 1 {
+		t.Errorf("Omega().Modulus(53) = %v, want 1", got)
+	}
+	if got := new(Stein).Modulus(53); got.Sign() != 0 {
+		t.Errorf("Modulus of zero = %v, want 0", got)
+	}
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		if x.Quad().Sign() != 0 && x.Modulus(53).Sign() <= 0 {
+			return false
+		}
+		l, _ := new(Stein).Mul(x, y).Modulus(53).Float64()
+		r, _ := new(big.Float).Mul(x.Modulus(53), y.Modulus(53)).Float64()
+		return math.Abs(l-r) <= 1e-14*math.Abs(l)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModulusFloat64(t *testing.T) {
+	f := func(a, b int32) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		z := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		x, y := z.Float64()
+		got, _ := z.Modulus(53).Float64()
+		return math.Abs(got-math.Hypot(x, y)) <= 1e-15*got
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFromPolarUnits(t *testing.T) {
+	for k, u := range units {
+		if got := FromPolar(1, float64(k)*math.Pi/3); !got.Equals(u) {
+			t.Errorf("FromPolar(1, %dπ/3) = %v, want %v", k, got, u)
+		}
+	}
+	if got := FromPolar(math.Inf(1), 0); got != nil {
+		t.Errorf("FromPolar(+Inf, 0) = %v, want nil", got)
+	}
+}
+
+func TestFromPolarRoundTrip(t *testing.T) {
+	f := func(a, b int32) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		z := New(big.NewInt(int64(a>>8)), big.NewInt(int64(b>>8)))
+		if z.Quad().Sign() == 0 {
+			return true
+		}
+		r, _ := z.Modulus(53).Float64()
+		theta, _ := z.Arg(53).Float64()
+		return FromPolar(r, theta).Equals(z)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCollinear(t *testing.T) {
+	var tests = []struct {
+		a, b, c *Stein
+		want    bool
+	}{
+		{new(Stein), Omega(), New(big.NewInt(0), big.NewInt(2)), true},
+		{new(Stein), New(big.NewInt(1), big.NewInt(1)), New(big.NewInt(-3), big.NewInt(-3)), true},
+		{New(big.NewInt(1), big.NewInt(0)), New(big.NewInt(3), big.NewInt(1)), New(big.NewInt(7), big.NewInt(3)), true},
+		{new(Stein), Omega(), New(big.NewInt(1), big.NewInt(0)), false},
+		{new(Stein), New(big.NewInt(1), big.NewInt(1)), New(big.NewInt(2), big.NewInt(1)), false},
+		{Omega(), Omega(), New(big.NewInt(5), big.NewInt(-2)), true},
+	}
+	for _, test := range tests {
+		if got := Collinear(test.a, test.b, test.c); got != test.want {
+			t.Errorf("Collinear(%v, %v, %v) = %v, want %v", test.a, test.b, test.c, got, test.want)
+		}
+	}
+}
+
+func TestCollinearLine(t *testing.T) {
+	f := func(a, d *Stein, s, u int16) bool {
+		// t.Logf("a = %v, d = %v, s = %v, u = %v", a, d, s, u)
+		b := new(Stein).Add(a, new(Stein).Scal(d, big.NewInt(int64(s))))
+		c := new(Stein).Add(a, new(Stein).Scal(d, big.NewInt(int64(u))))
+		// Turning the direction by 60° leaves the line, unless d is zero.
+		e := new(Stein).Add(b, new(Stein).rotate(d, 1))
+		return Collinear(a, b, c) && Collinear(c, a, b) &&
+			Collinear(a, b, e) == (d.Quad().Sign() == 0 || s == 0)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrossAntiSymmetric(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		l, r := Cross(x, y), Cross(y, x)
+		return l.Cmp(r.Neg(r)) == 0 && Cross(x, x).Sign() == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCrossConjMul(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		p := new(Stein).Mul(new(Stein).Conj(x), y)
+		_, b := p.Integers()
+		return Cross(x, y).Cmp(b) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	if got := Cross(New(big.NewInt(1), big.NewInt(0)), Omega()); got.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("Cross(1, ω) = %v, want 1", got)
+	}
+}
+
+// inHull returns true if p lies inside or on the boundary of the convex
+// polygon hull, given in counterclockwise order.
+func inHull(p *Stein, hull []*Stein) bool {
+	for i, a := range hull {
+		b := hull[(i+1)%len(hull)]
+		if Cross(new(Stein).Sub(b, a), new(Stein).Sub(p, a)).Sign() < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestConvexHullSquare(t *testing.T) {
+	var points []*Stein
+	for a := int64(0); a <= 3; a++ {
+		for b := int64(0); b <= 3; b++ {
+			points = append(points, New(big.NewInt(a), big.NewInt(b)))
+		}
+	}
+	want := []*Stein{
+		New(big.NewInt(0), big.NewInt(0)),
+		New(big.NewInt(3), big.NewInt(0)),
+		New(big.NewInt(3), big.NewInt(3)),
+		New(big.NewInt(0), big.NewInt(3)),
+	}
+	got := ConvexHull(points)
+	if len(got) != len(want) {
+		t.Fatalf("ConvexHull(square) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equals(want[i]) {
+			t.Errorf("ConvexHull(square) = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	f := func(coords []int8) bool {
+		// t.Logf("coords = %v", coords)
+		var points []*Stein
+		for i := 0; i+1 < len(coords); i += 2 {
+			points = append(points, New(big.NewInt(int64(coords[i])), big.NewInt(int64(coords[i+1]))))
+		}
+		hull := ConvexHull(points)
+		switch len(hull) {
+		case 0:
+			return len(points) == 0
+		case 1:
+			for _, p := range points {
+				if !p.Equals(hull[0]) {
+					return false
+				}
+			}
+			return true
+		case 2:
+			// All points lie on the segment between the two.
+			for _, p := range points {
+				if !Collinear(hull[0], hull[1], p) || cmp(hull[0], p) > 0 || cmp(p, hull[1]) > 0 {
+					return false
+				}
+			}
+			return true
+		}
+		for i := range hull {
+			a, b, c := hull[i], hull[(i+1)%len(hull)], hull[(i+2)%len(hull)]
+			if Cross(new(Stein).Sub(b, a), new(Stein).Sub(c, b)).Sign() <= 0 {
+				return false
+			}
+		}
+		for _, p := range points {
+			if !inHull(p, hull) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConvexHullDegenerate(t *testing.T) {
+	if got := ConvexHull(nil); len(got) != 0 {
+		t.Errorf("ConvexHull(nil) = %v, want []", got)
+	}
+	p := New(big.NewInt(2), big.NewInt(-1))
+	if got := ConvexHull([]*Stein{p, p, p}); len(got) != 1 || !got[0].Equals(p) {
+		t.Errorf("ConvexHull([p, p, p]) = %v, want [%v]", got, p)
+	}
+	line := []*Stein{Omega(), New(big.NewInt(0), big.NewInt(3)), new(Stein), New(big.NewInt(0), big.NewInt(2))}
+	got := ConvexHull(line)
+	if len(got) != 2 || !got[0].Equals(new(Stein)) || !got[1].Equals(line[1]) {
+		t.Errorf("ConvexHull(%v) = %v, want [0, 3ω]", line, got)
+	}
+}
+
+// triangleInteriorBruteForce counts the lattice points strictly inside the
+// triangle a, b, c by checking every point of its bounding box.
+func triangleInteriorBruteForce(a, b, c *Stein) int64 {
+	lo, hi := new(Stein).Set(a), new(Stein).Set(a)
+	for _, v := range []*Stein{b, c} {
+		if v.l.Cmp(&lo.l) < 0 {
+			lo.l.Set(&v.l)
+		}
+		if v.r.Cmp(&lo.r) < 0 {
+			lo.r.Set(&v.r)
+		}
+		if v.l.Cmp(&hi.l) > 0 {
+			hi.l.Set(&v.l)
+		}
+		if v.r.Cmp(&hi.r) > 0 {
+			hi.r.Set(&v.r)
+		}
+	}
+	count := int64(0)
+	p, u, v := new(Stein), new(Stein), new(Stein)
+	for x := lo.l.Int64(); x <= hi.l.Int64(); x++ {
+		for y := lo.r.Int64(); y <= hi.r.Int64(); y++ {
+			p.SetInt64s(x, y)
+			signs := 0
+			for _, edge := range [3][2]*Stein{{a, b}, {b, c}, {c, a}} {
+				signs += Cross(u.Sub(edge[1], edge[0]), v.Sub(p, edge[0])).Sign()
+			}
+			if signs == 3 || signs == -3 {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestLatticePointsInTriangle(t *testing.T) {
+	f := func(a, b, c, d, e, g int8) bool {
+		// t.Logf("a = %v, b = %v, c = %v, d = %v, e = %v, g = %v", a, b, c, d, e, g)
+		x := New(big.NewInt(int64(a%20)), big.NewInt(int64(b%20)))
+		y := New(big.NewInt(int64(c%20)), big.NewInt(int64(d%20)))
+		z := New(big.NewInt(int64(e%20)), big.NewInt(int64(g%20)))
+		return LatticePointsInTriangle(x, y, z).Int64() == triangleInteriorBruteForce(x, y, z)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	// The triangle 0, 3, 3ω has the single point 1+ω inside.
+	x, y := New(big.NewInt(3), big.NewInt(0)), New(big.NewInt(0), big.NewInt(3))
+	if got := LatticePointsInTriangle(new(Stein), x, y); got.Int64() != 1 {
+		t.Errorf("LatticePointsInTriangle(0, %v, %v) = %v, want 1", x, y, got)
+	}
+}
+
+func TestNearestNeighbor(t *testing.T) {
+	points := []*Stein{
+		New(big.NewInt(3), big.NewInt(0)),
+		New(big.NewInt(0), big.NewInt(3)),
+		New(big.NewInt(3), big.NewInt(3)),
+		New(big.NewInt(1), big.NewInt(-1)),
+		New(big.NewInt(-2), big.NewInt(0)),
+	}
+	var tests = []struct {
+		query, want *Stein
+		dist        int64
+	}{
+		{New(big.NewInt(3), big.NewInt(0)), New(big.NewInt(3), big.NewInt(0)), 0},
+		{New(big.NewInt(-1), big.NewInt(-1)), New(big.NewInt(-2), big.NewInt(0)), 3},
+		// 3+3ω and 1-ω are both at distance 4 from 1+ω.
+		{New(big.NewInt(1), big.NewInt(1)), New(big.NewInt(1), big.NewInt(-1)), 4},
+	}
+	for _, test := range tests {
+		got, dist := NearestNeighbor(test.query, points)
+		if !got.Equals(test.want) || dist.Int64() != test.dist {
+			t.Errorf("NearestNeighbor(%v) = %v, %v, want %v, %d", test.query, got, dist, test.want, test.dist)
+		}
+	}
+	if got, dist := NearestNeighbor(new(Stein), nil); got != nil || dist != nil {
+		t.Errorf("NearestNeighbor of no points = %v, %v, want nil, nil", got, dist)
+	}
+}
+
+func TestNearestNeighborBruteForce(t *testing.T) {
+	f := func(q *Stein, ps [8]*Stein) bool {
+		// t.Logf("q = %v, ps = %v", q, ps)
+		got, dist := NearestNeighbor(q, ps[:])
+		found := false
+		for _, p := range ps {
+			if p.Dist(q).Cmp(dist) < 0 {
+				return false
+			}
+			found = found || p.Equals(got)
+		}
+		return found && got.Dist(q).Cmp(dist) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"errors"
+	"math/big"
+)
+
+// errNotCube is returned by FromCube for coordinates that do not sum to
+// zero.
+var errNotCube = errors.New("eisen: cube coordinates do not sum to zero")
+
+// CubeCoords returns the cube coordinates of z on the hexagonal grid whose
+// cells are centered on the Eisenstein integers. If z = a+bω, then the
+// coordinates are x = a, y = -b, and s = b - a, so that x + y + s = 0 and
+// each of the six units moves exactly two coordinates by one.
+func (z *Stein) CubeCoords() (x, y, s *big.Int) {
+	x = new(big.Int).Set(&z.l)
+	y = new(big.Int).Neg(&z.r)
+	s = new(big.Int).Sub(&z.r, &z.l)
+	return
+}
+
+// FromCube returns a pointer to the Stein value with the cube coordinates
+// x, y, and s, as given by CubeCoords. It returns an error if the
+// coordinates do not sum to zero.
+func FromCube(x, y, s *big.Int) (*Stein, error) {
+	sum := new(big.Int).Add(x, y)
+	if sum.Add(sum, s).Sign() != 0 {
+		return nil, errNotCube
+	}
+	return New(x, new(big.Int).Neg(y)), nil
+}
+
+// Neighbors returns the six lattice points adjacent to z, that is, z plus
+// each of the six units, in counterclockwise order starting from z+1.
+func (z *Stein) Neighbors() [6]*Stein {
+	var n [6]*Stein
+	for k, u := range units {
+		n[k] = new(Stein).Add(z, u)
+	}
+	return n
+}
+
+// HexDistance returns the number of steps between z and y on the hexagonal
+// grid, where each step moves to a neighbor. It is the largest absolute
+// difference of the cube coordinates of z and y.
+func (z *Stein) HexDistance(y *Stein) *big.Int {
+	d := new(Stein).Sub(z, y)
+	x1, y1, s1 := d.CubeCoords()
+	dist := x1.Abs(x1)
+	if y1.Abs(y1).Cmp(dist) > 0 {
+		dist = y1
+	}
+	if s1.Abs(s1).Cmp(dist) > 0 {
+		dist = s1
+	}
+	return dist
+}
+
+// HexLine returns the lattice points on the hexagonal grid that approximate
+// the straight segment from a to b, starting with a and ending with b. There
+// are HexDistance(a, b) + 1 points, and consecutive points are neighbors.
+//
+// The points are found by rounding equally spaced points of the segment in
+// cube coordinates. The segment is nudged by a small amount, well below the
+// spacing, so that no point lies exactly on the boundary between cells.
+func HexLine(a, b *Stein) []*Stein {
+	n := a.HexDistance(b)
+	if n.Sign() == 0 {
+		return []*Stein{new(Stein).Set(a)}
+	}
+	// The i-th point has cube coordinates c + i(d - c)/n, which are scaled
+	// by m = 10n to keep them integral. The nudge is (1, 2, -3)/m.
+	m := new(big.Int).Mul(n, big.NewInt(10))
+	x0, y0, s0 := a.CubeCoords()
+	x1, y1, s1 := b.CubeCoords()
+	start := [3]*big.Int{x0, y0, s0}
+	delta := [3]*big.Int{x1.Sub(x1, x0), y1.Sub(y1, y0), s1.Sub(s1, s0)}
+	nudge := [3]int64{1, 2, -3}
+	line := make([]*Stein, 0, n.Int64()+1)
+	ten := big.NewInt(10)
+	for i := new(big.Int); i.Cmp(n) <= 0; i.Add(i, big.NewInt(1)) {
+		var c [3]*big.Int
+		for j := range c {
+			c[j] = new(big.Int).Mul(m, start[j])
+			c[j].Add(c[j], new(big.Int).Mul(new(big.Int).Mul(ten, i), delta[j]))
+			c[j].Add(c[j], big.NewInt(nudge[j]))
+		}
+		line = append(line, cubeRound(c, m))
+	}
+	return line
+}
+
+// cubeRound returns the lattice point whose cell contains the point with
+// cube coordinates c/m, for positive m. Each coordinate is rounded, and the
+// one that moved the most is then fixed by the constraint x + y + s = 0.
+func cubeRound(c [3]*big.Int, m *big.Int) *Stein {
+	var r, diff [3]*big.Int
+	twoM := new(big.Int).Lsh(m, 1)
+	for j := range c {
+		r[j] = new(big.Int).Lsh(c[j], 1)
+		r[j].Add(r[j], m)
+		r[j].Div(r[j], twoM)
+		diff[j] = new(big.Int).Mul(m, r[j])
+		diff[j].Sub(c[j], diff[j])
+		diff[j].Abs(diff[j])
+	}
+	fix := 2
+	if diff[0].Cmp(diff[1]) > 0 && diff[0].Cmp(diff[2]) > 0 {
+		fix = 0
+	} else if diff[1].Cmp(diff[2]) > 0 {
+		fix = 1
+	}
+	r[fix].Add(r[(fix+1)%3], r[(fix+2)%3])
+	r[fix].Neg(r[fix])
+	return New(r[0], r[1].Neg(r[1]))
+}
+
+// Spiral returns the first n lattice points of an outward hexagonal spiral
+// around the origin. The origin comes first, followed by the rings at hex
+// distance 1, 2, and so on. Ring k has 6k points; it starts at k and goes
+// counterclockwise, each point a neighbor of the one before. Spiral returns
+// an empty slice when n is not positive.
+func Spiral(n int) []*Stein {
+	if n <= 0 {
+		return []*Stein{}
+	}
+	spiral := make([]*Stein, 0, n)
+	spiral = append(spiral, new(Stein))
+	for k := int64(1); len(spiral) < n; k++ {
+		p := New(big.NewInt(k), new(big.Int))
+		for j := 0; j < 6; j++ {
+			// Walking k steps along units[j+2] takes k·units[j] to k·units[j+1].
+			for s := int64(0); s < k && len(spiral) < n; s++ {
+				spiral = append(spiral, new(Stein).Set(p))
+				p.Add(p, units[(j+2)%6])
+			}
+		}
+	}
+	return spiral
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestCubeCoordsRoundTrip(t *testing.T) {
+	f := func(z *Stein) bool {
+		// t.Logf("z = %v", z)
+		x, y, s := z.CubeCoords()
+		sum := new(big.Int).Add(x, y)
+		w, err := FromCube(x, y, s)
+		return sum.Add(sum, s).Sign() == 0 && err == nil && w.Equals(z)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCubeCoordsUnits(t *testing.T) {
+	for _, u := range units {
+		x, y, s := u.CubeCoords()
+		abs := new(big.Int).Abs(x)
+		abs.Add(abs, new(big.Int).Abs(y))
+		abs.Add(abs, new(big.Int).Abs(s))
+		if abs.Cmp(big.NewInt(2)) != 0 {
+			t.Errorf("%v.CubeCoords() = (%v, %v, %v), want a unit step", u, x, y, s)
+		}
+	}
+}
+
+func TestFromCubeInvalid(t *testing.T) {
+	if _, err := FromCube(big.NewInt(1), big.NewInt(1), big.NewInt(1)); err == nil {
+		t.Error("FromCube(1, 1, 1) succeeded, want error")
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	f := func(z *Stein) bool {
+		// t.Logf("z = %v", z)
+		n := z.Neighbors()
+		for i, a := range n {
+			if z.Dist(a).Cmp(big.NewInt(1)) != 0 {
+				return false
+			}
+			for _, b := range n[:i] {
+				if a.Equals(b) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHexDistanceNeighbors(t *testing.T) {
+	f := func(z *Stein) bool {
+		// t.Logf("z = %v", z)
+		for _, n := range z.Neighbors() {
+			if z.HexDistance(n).Cmp(big.NewInt(1)) != 0 {
+				return false
+			}
+		}
+		return z.HexDistance(z).Sign() == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHexDistanceMetric(t *testing.T) {
+	f := func(x, y, z *Stein) bool {
+		// t.Logf("x = %v, y = %v, z = %v", x, y, z)
+		xy, yz := x.HexDistance(y), y.HexDistance(z)
+		return xy.Cmp(y.HexDistance(x)) == 0 &&
+			x.HexDistance(z).Cmp(new(big.Int).Add(xy, yz)) <= 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHexDistanceBreadthFirst(t *testing.T) {
+	// A breadth-first search from zero finds the true step counts.
+	dist := map[string]int64{new(Stein).String(): 0}
+	frontier := []*Stein{new(Stein)}
+	for step := int64(1); step <= 6; step++ {
+		var next []*Stein
+		for _, z := range frontier {
+			for _, n := range z.Neighbors() {
+				if _, ok := dist[n.String()]; !ok {
+					dist[n.String()] = step
+					next = append(next, n)
+				}
+			}
+		}
+		frontier = next
+	}
+	for _, z := range SteinsUpToNorm(25) {
+		if got := z.HexDistance(new(Stein)).Int64(); got != dist[z.String()] {
+			t.Errorf("HexDistance(%v, 0) = %d, want %d", z, got, dist[z.String()])
+		}
+	}
+}
+
+func TestHexLine(t *testing.T) {
+	f := func(a, b, c, d int16) bool {
+		// t.Logf("a = %v, b = %v, c = %v, d = %v", a, b, c, d)
+		x := New(big.NewInt(int64(a%200)), big.NewInt(int64(b%200)))
+		y := New(big.NewInt(int64(c%200)), big.NewInt(int64(d%200)))
+		line := HexLine(x, y)
+		if int64(len(line)) != x.HexDistance(y).Int64()+1 {
+			return false
+		}
+		if !line[0].Equals(x) || !line[len(line)-1].Equals(y) {
+			return false
+		}
+		for i := 1; i < len(line); i++ {
+			if line[i-1].HexDistance(line[i]).Cmp(big.NewInt(1)) != 0 {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestHexLineBetweenNeighbors(t *testing.T) {
+	// From zero to 1+2ω, the midpoint lies on the boundary of the cells of
+	// ω and 1+ω, so the nudge must pick one of them.
+	x, y := new(Stein), New(big.NewInt(1), big.NewInt(2))
+	line := HexLine(x, y)
+	if len(line) != 3 {
+		t.Fatalf("HexLine(%v, %v) = %v, want 3 points", x, y, line)
+	}
+	if !line[1].Equals(Omega()) && !line[1].Equals(New(big.NewInt(1), big.NewInt(1))) {
+		t.Errorf("HexLine(%v, %v) = %v, want ω or 1+ω in the middle", x, y, line)
+	}
+	if got := HexLine(y, y); len(got) != 1 || !got[0].Equals(y) {
+		t.Errorf("HexLine(%v, %v) = %v, want [%v]", y, y, got, y)
+	}
+}
+
+func TestSpiral(t *testing.T) {
+	const rings = 6
+	n := 1 + 3*rings*(rings+1)
+	spiral := Spiral(n)
+	if len(spiral) != n {
+		t.Fatalf("len(Spiral(%d)) = %d, want %d", n, len(spiral), n)
+	}
+	if !spiral[0].Equals(new(Stein)) {
+		t.Errorf("Spiral(%d)[0] = %v, want 0", n, spiral[0])
+	}
+	zero := new(Stein)
+	seen := make(map[string]bool)
+	for _, p := range spiral {
+		seen[p.String()] = true
+	}
+	if len(seen) != n {
+		t.Errorf("Spiral(%d) has %d distinct points, want %d", n, len(seen), n)
+	}
+	i := 1
+	for k := 1; k <= rings; k++ {
+		ring := spiral[i : i+6*k]
+		for j, p := range ring {
+			// t.Logf("k = %d, p = %v", k, p)
+			if p.HexDistance(zero).Int64() != int64(k) {
+				t.Errorf("point %v of ring %d is at distance %v", p, k, p.HexDistance(zero))
+			}
+			if j > 0 && ring[j-1].HexDistance(p).Int64() != 1 {
+				t.Errorf("points %v and %v of ring %d are not neighbors", ring[j-1], p, k)
+			}
+		}
+		i += 6 * k
+	}
+	if got := Spiral(0); len(got) != 0 {
+		t.Errorf("Spiral(0) = %v, want []", got)
+	}
+}
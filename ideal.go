@@ -0,0 +1,29 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import "math/big"
+
+// IdealNorm returns the norm of the ideal generated by gens, which is the
+// number of residues modulo the ideal. Since ℤ[ω] is a principal ideal
+// domain, the ideal is generated by the greatest common divisor of gens, and
+// its norm is the quadrance of that divisor. The zero ideal, generated by no
+// values or only by zeros, has norm zero.
+func IdealNorm(gens ...*Stein) *big.Int {
+	return GCDSlice(gens).Quad()
+}
+
+// InIdeal returns true if x lies in the ideal generated by gens, that is,
+// if x is divisible by the greatest common divisor of gens. With no
+// generators the ideal is zero, and only zero lies in it.
+func InIdeal(x *Stein, gens ...*Stein) bool {
+	return GCDSlice(gens).Divides(x)
+}
+
+// SameIdeal returns true if a and b generate the same principal ideal, that
+// is, if each divides the other. In ℤ[ω] this holds exactly when a and b are
+// associates, so the zero ideal is generated only by zero.
+func SameIdeal(a, b *Stein) bool {
+	return a.IsAssociate(b)
+}
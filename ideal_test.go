@@ -0,0 +1,126 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestIdealNormPrincipal(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		return IdealNorm(x).Cmp(x.Quad()) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIdealNormCoprime(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	f := func(a, b, c, d int8) bool {
+		// t.Logf("a = %v, b = %v, c = %v, d = %v", a, b, c, d)
+		x := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		y := New(big.NewInt(int64(c)), big.NewInt(int64(d)))
+		if !new(Stein).GCD(nil, nil, x, y).Equals(one) {
+			return true
+		}
+		return IdealNorm(x, y).Cmp(big.NewInt(1)) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIdealNormExamples(t *testing.T) {
+	var tests = []struct {
+		gens []*Stein
+		want int64
+	}{
+		{nil, 0},
+		{[]*Stein{new(Stein), new(Stein)}, 0},
+		{[]*Stein{New(big.NewInt(6), big.NewInt(0)), New(big.NewInt(4), big.NewInt(0))}, 4},
+		{[]*Stein{New(big.NewInt(3), big.NewInt(0)), New(big.NewInt(2), big.NewInt(1))}, 3},
+		{[]*Stein{New(big.NewInt(7), big.NewInt(0)), New(big.NewInt(3), big.NewInt(1))}, 7},
+	}
+	for _, test := range tests {
+		if got := IdealNorm(test.gens...); got.Int64() != test.want {
+			t.Errorf("IdealNorm(%v) = %v, want %d", test.gens, got, test.want)
+		}
+	}
+}
+
+func TestInIdealGenerators(t *testing.T) {
+	f := func(x, y, s, u *Stein) bool {
+		// t.Logf("x = %v, y = %v, s = %v, u = %v", x, y, s, u)
+		comb := new(Stein).Mul(x, s)
+		comb.Add(comb, new(Stein).Mul(y, u))
+		return InIdeal(x, x, y) && InIdeal(y, x, y) && InIdeal(comb, x, y)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInIdealNonMember(t *testing.T) {
+	f := func(x, s *Stein) bool {
+		// t.Logf("x = %v, s = %v", x, s)
+		if x.Quad().Cmp(big.NewInt(1)) <= 0 {
+			return true
+		}
+		// A multiple of x plus one is a unit modulo x, so it is not in (x).
+		y := new(Stein).Mul(x, s)
+		y.Add(y, New(big.NewInt(1), big.NewInt(0)))
+		return !InIdeal(y, x) && !InIdeal(y, x, new(Stein).Mul(x, x))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInIdealZero(t *testing.T) {
+	if !InIdeal(new(Stein)) {
+		t.Error("zero is not in the zero ideal")
+	}
+	if InIdeal(Omega()) || InIdeal(Omega(), new(Stein)) {
+		t.Error("ω is in the zero ideal")
+	}
+}
+
+func TestSameIdeal(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		for _, u := range units {
+			v := new(Stein).Mul(u, x)
+			if !SameIdeal(x, v) || !SameIdeal(v, x) {
+				return false
+			}
+		}
+		if x.Quad().Sign() == 0 {
+			return true
+		}
+		double := new(Stein).Scal(x, big.NewInt(2))
+		return !SameIdeal(x, double) && !SameIdeal(x, new(Stein))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	if !SameIdeal(new(Stein), new(Stein)) {
+		t.Error("SameIdeal(0, 0) = false, want true")
+	}
+}
+
+func TestSameIdealDivides(t *testing.T) {
+	xs := SteinsUpToNorm(13)
+	for _, a := range xs {
+		for _, b := range xs {
+			want := a.Divides(b) && b.Divides(a)
+			if got := SameIdeal(a, b); got != want {
+				t.Errorf("SameIdeal(%v, %v) = %v, want %v", a, b, got, want)
+			}
+		}
+	}
+}
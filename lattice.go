@@ -0,0 +1,59 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math"
+	"math/big"
+	"sort"
+)
+
+// isqrt returns the integer square root of a non-negative n.
+func isqrt(n int64) int64 {
+	s := int64(math.Sqrt(float64(n)))
+	for s*s > n {
+		s--
+	}
+	for (s+1)*(s+1) <= n {
+		s++
+	}
+	return s
+}
+
+// SteinsUpToNorm returns every Eisenstein integer whose quadrance is at most
+// bound, in order of increasing quadrance. Values of equal quadrance are
+// ordered lexicographically by their components.
+func SteinsUpToNorm(bound int64) []*Stein {
+	if bound < 0 {
+		return nil
+	}
+	type point struct {
+		a, b, quad int64
+	}
+	var points []point
+	// Since 4(a² - ab + b²) = (2a - b)² + 3b², the box is |b| <= √(4N/3),
+	// and |2a - b| <= √(4N - 3b²) for each b.
+	maxB := isqrt(4 * bound / 3)
+	for b := -maxB; b <= maxB; b++ {
+		s := isqrt(4*bound - 3*b*b)
+		for a := -((s - b) >> 1); a <= (b+s)>>1; a++ {
+			points = append(points, point{a, b, a*a - a*b + b*b})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		p, q := points[i], points[j]
+		if p.quad != q.quad {
+			return p.quad < q.quad
+		}
+		if p.a != q.a {
+			return p.a < q.a
+		}
+		return p.b < q.b
+	})
+	steins := make([]*Stein, len(points))
+	for i, p := range points {
+		steins[i] = New(big.NewInt(p.a), big.NewInt(p.b))
+	}
+	return steins
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSteinsUpToNormCount(t *testing.T) {
+	// Partial sums of the theta series 1 + 6q + 6q³ + 6q⁴ + 12q⁷ + ... of
+	// the hexagonal lattice.
+	want := []int{1, 7, 7, 13, 19, 19, 19, 31, 31, 37, 37, 37, 43, 55}
+	for n, w := range want {
+		if got := len(SteinsUpToNorm(int64(n))); got != w {
+			t.Errorf("len(SteinsUpToNorm(%d)) = %d, want %d", n, got, w)
+		}
+	}
+	if got := SteinsUpToNorm(-1); len(got) != 0 {
+		t.Errorf("SteinsUpToNorm(-1) = %v, want empty", got)
+	}
+}
+
+func TestSteinsUpToNormOrder(t *testing.T) {
+	bound := int64(200)
+	steins := SteinsUpToNorm(bound)
+	for i, z := range steins {
+		if z.Quad().Cmp(big.NewInt(bound)) > 0 {
+			t.Errorf("%v has quadrance %v > %d", z, z.Quad(), bound)
+		}
+		if i > 0 {
+			prev := steins[i-1]
+			c := prev.Quad().Cmp(z.Quad())
+			if c > 0 || c == 0 && cmp(prev, z) >= 0 {
+				t.Errorf("%v listed before %v", prev, z)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"errors"
+	"math/big"
+)
+
+// errNotRegular is returned by FromMatrix for a matrix that is not the
+// matrix of a Stein value.
+var errNotRegular = errors.New("eisen: matrix is not of the form [[a, -b], [b, a-b]]")
+
+// Matrix returns the matrix of multiplication by z on ℤ[ω], in the basis
+// {1, ω}. If z = a+bω, then the matrix is
+//
+//	[[a, -b], [b, a-b]]
+//
+// whose columns are the components of z and Mul(z, ω). The determinant of
+// the matrix is the quadrance of z.
+func (z *Stein) Matrix() [2][2]*big.Int {
+	return [2][2]*big.Int{
+		{new(big.Int).Set(&z.l), new(big.Int).Neg(&z.r)},
+		{new(big.Int).Set(&z.r), new(big.Int).Sub(&z.l, &z.r)},
+	}
+}
+
+// FromMatrix returns a pointer to the Stein value whose matrix, as given by
+// Matrix, is m. It returns an error if m is not of that form.
+func FromMatrix(m [2][2]*big.Int) (*Stein, error) {
+	for _, row := range m {
+		for _, e := range row {
+			if e == nil {
+				return nil, errNotRegular
+			}
+		}
+	}
+	if new(big.Int).Neg(m[1][0]).Cmp(m[0][1]) != 0 ||
+		new(big.Int).Sub(m[0][0], m[1][0]).Cmp(m[1][1]) != 0 {
+		return nil, errNotRegular
+	}
+	return New(m[0][0], m[1][0]), nil
+}
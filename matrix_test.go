@@ -0,0 +1,74 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+// matMul returns the product of the 2×2 matrices m and n.
+func matMul(m, n [2][2]*big.Int) [2][2]*big.Int {
+	var p [2][2]*big.Int
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			p[i][j] = new(big.Int).Mul(m[i][0], n[0][j])
+			p[i][j].Add(p[i][j], new(big.Int).Mul(m[i][1], n[1][j]))
+		}
+	}
+	return p
+}
+
+func TestMatrixMul(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		l := new(Stein).Mul(x, y).Matrix()
+		r := matMul(x.Matrix(), y.Matrix())
+		for i := 0; i < 2; i++ {
+			for j := 0; j < 2; j++ {
+				if l[i][j].Cmp(r[i][j]) != 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMatrixDet(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		m := x.Matrix()
+		det := new(big.Int).Mul(m[0][0], m[1][1])
+		det.Sub(det, new(big.Int).Mul(m[0][1], m[1][0]))
+		return det.Cmp(x.Quad()) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFromMatrix(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		y, err := FromMatrix(x.Matrix())
+		return err == nil && y.Equals(x)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	m := New(big.NewInt(2), big.NewInt(5)).Matrix()
+	m[1][1].Add(m[1][1], big.NewInt(1))
+	if _, err := FromMatrix(m); err == nil {
+		t.Errorf("FromMatrix(%v) succeeded, want error", m)
+	}
+	m[1][1] = nil
+	if _, err := FromMatrix(m); err == nil {
+		t.Errorf("FromMatrix with nil entry succeeded, want error")
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"errors"
+	"math/big"
+)
+
+// EvalPoly returns the value at x of the polynomial whose coefficient of xⁱ
+// is coeffs[i], using Horner's method. The polynomial with no coefficients
+// is zero.
+func EvalPoly(coeffs []*Stein, x *Stein) *Stein {
+	z := new(Stein)
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		z.Mul(z, x)
+		z.Add(z, coeffs[i])
+	}
+	return z
+}
+
+// A SteinPoly represents a polynomial with Eisenstein integer coefficients.
+// The coefficient of xⁱ is stored at index i. The methods of SteinPoly
+// return polynomials without trailing zero coefficients, so the zero
+// polynomial is empty.
+type SteinPoly []*Stein
+
+// trim returns p without its trailing zero coefficients.
+func (p SteinPoly) trim() SteinPoly {
+	n := len(p)
+	for n > 0 && p[n-1].l.Sign() == 0 && p[n-1].r.Sign() == 0 {
+		n--
+	}
+	return p[:n]
+}
+
+// Degree returns the degree of p. The degree of the zero polynomial is -1.
+func (p SteinPoly) Degree() int {
+	return len(p.trim()) - 1
+}
+
+// Add returns the sum of p and q.
+func (p SteinPoly) Add(q SteinPoly) SteinPoly {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	sum := make(SteinPoly, len(p))
+	for i := range p {
+		sum[i] = new(Stein).Set(p[i])
+		if i < len(q) {
+			sum[i].Add(sum[i], q[i])
+		}
+	}
+	return sum.trim()
+}
+
+// Mul returns the product of p and q.
+func (p SteinPoly) Mul(q SteinPoly) SteinPoly {
+	p, q = p.trim(), q.trim()
+	if len(p) == 0 || len(q) == 0 {
+		return SteinPoly{}
+	}
+	prod := make(SteinPoly, len(p)+len(q)-1)
+	for i := range prod {
+		prod[i] = new(Stein)
+	}
+	temp := new(Stein)
+	for i, a := range p {
+		for j, b := range q {
+			prod[i+j].Add(prod[i+j], temp.Mul(a, b))
+		}
+	}
+	return prod.trim()
+}
+
+// Eval returns the value of p at x.
+func (p SteinPoly) Eval(x *Stein) *Stein {
+	return EvalPoly(p, x)
+}
+
+// Content returns the greatest common divisor of the coefficients of p, in
+// the same normal form as GCD. The content of the zero polynomial is zero.
+func (p SteinPoly) Content() *Stein {
+	return GCDSlice(p)
+}
+
+// Primitive returns p divided by its content, which is a polynomial whose
+// content is one. The zero polynomial is returned as it is, without
+// trailing zero coefficients.
+func (p SteinPoly) Primitive() SteinPoly {
+	p = p.trim()
+	c := p.Content()
+	prim := make(SteinPoly, len(p))
+	for i := range p {
+		prim[i] = new(Stein).Quo(p[i], c)
+	}
+	return prim
+}
+
+// errNotMonic is returned by SteinPoly.QuoRem when the leading coefficient
+// of the divisor is not a unit.
+var errNotMonic = errors.New("eisen: leading coefficient is not a unit")
+
+// QuoRem returns the quotient q and remainder r of the long division of p by
+// d, such that p = q*d + r and the degree of r is less than the degree of d.
+// Since ℤ[ω] is not a field, the leading coefficient of d must be a unit;
+// otherwise, or if d is zero, QuoRem returns an error.
+func (p SteinPoly) QuoRem(d SteinPoly) (q, r SteinPoly, err error) {
+	d = d.trim()
+	if len(d) == 0 {
+		return nil, nil, errZero
+	}
+	lead := d[len(d)-1]
+	if lead.Quad().Cmp(big.NewInt(1)) != 0 {
+		return nil, nil, errNotMonic
+	}
+	// The inverse of a unit is its conjugate.
+	inv := new(Stein).Conj(lead)
+	r = make(SteinPoly, len(p))
+	for i := range p {
+		r[i] = new(Stein).Set(p[i])
+	}
+	r = r.trim()
+	if len(r) < len(d) {
+		return SteinPoly{}, r, nil
+	}
+	q = make(SteinPoly, len(r)-len(d)+1)
+	temp := new(Stein)
+	for k := len(q) - 1; k >= 0; k-- {
+		q[k] = new(Stein).Mul(r[k+len(d)-1], inv)
+		for j, c := range d {
+			r[k+j].Sub(r[k+j], temp.Mul(q[k], c))
+		}
+	}
+	return q.trim(), r.trim(), nil
+}
+
+// PolyGCD returns the greatest common divisor of a and b, found by the
+// Euclidean algorithm over the field ℚ(ω) with Ratein coefficients. The
+// result is scaled back to a primitive polynomial over ℤ[ω] whose leading
+// coefficient is normalized as in Abs, so it divides a and b over ℤ[ω] and
+// is a multiple of every primitive common divisor. It is unique up to a unit
+// and a constant factor from ℤ[ω]; in particular, a common constant factor of
+// a and b is not part of the result. The gcd of two polynomials with no
+// common factor is one, and the gcd of two zero polynomials is zero.
+func PolyGCD(a, b SteinPoly) SteinPoly {
+	x, y := ratPoly(a), ratPoly(b)
+	for len(y) > 0 {
+		x, y = y, ratPolyRem(x, y)
+	}
+	if len(x) == 0 {
+		return SteinPoly{}
+	}
+	// Clear the denominators with their least common multiple.
+	lcm := big.NewInt(1)
+	nums := make([]*Stein, len(x))
+	dens := make([]*big.Int, len(x))
+	for i, c := range x {
+		nums[i], dens[i] = c.NumDenom()
+		g := new(big.Int).GCD(nil, nil, lcm, dens[i])
+		lcm.Mul(lcm, new(big.Int).Quo(dens[i], g))
+	}
+	g := make(SteinPoly, len(x))
+	for i := range x {
+		g[i] = nums[i].Scal(nums[i], dens[i].Quo(lcm, dens[i]))
+	}
+	g = g.Primitive()
+	_, k := new(Stein).ToSextant(g[len(g)-1])
+	for _, c := range g {
+		c.Mul(c, units[k])
+	}
+	return g
+}
+
+// ratPoly returns the coefficients of p as Ratein values, without trailing
+// zeros.
+func ratPoly(p SteinPoly) []*Ratein {
+	p = p.trim()
+	q := make([]*Ratein, len(p))
+	for i, c := range p {
+		q[i] = FromStein(c)
+	}
+	return q
+}
+
+// ratPolyRem returns the remainder of the long division of p by the nonzero
+// polynomial d over ℚ(ω), without trailing zeros. Neither p nor d is
+// modified.
+func ratPolyRem(p, d []*Ratein) []*Ratein {
+	r := make([]*Ratein, len(p))
+	for i := range p {
+		r[i] = new(Ratein).Set(p[i])
+	}
+	lead := d[len(d)-1]
+	q, temp := new(Ratein), new(Ratein)
+	for k := len(r) - len(d); k >= 0; k-- {
+		q.Quo(r[k+len(d)-1], lead)
+		for j, c := range d {
+			r[k+j].Sub(r[k+j], temp.Mul(q, c))
+		}
+	}
+	n := len(r)
+	for n > 0 && r[n-1].l.Sign() == 0 && r[n-1].r.Sign() == 0 {
+		n--
+	}
+	return r[:n]
+}
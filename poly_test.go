@@ -0,0 +1,276 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestEvalPolyPowerSum(t *testing.T) {
+	f := func(coeffs []*Stein, x *Stein) bool {
+		// t.Logf("coeffs = %v, x = %v", coeffs, x)
+		sum := new(Stein)
+		power := New(big.NewInt(1), big.NewInt(0))
+		for _, c := range coeffs {
+			sum.Add(sum, new(Stein).Mul(c, power))
+			power.Mul(power, x)
+		}
+		return EvalPoly(coeffs, x).Equals(sum)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEvalPolyLowDegree(t *testing.T) {
+	f := func(a, b, x *Stein) bool {
+		// t.Logf("a = %v, b = %v, x = %v", a, b, x)
+		linear := new(Stein).Mul(b, x)
+		linear.Add(linear, a)
+		return EvalPoly(nil, x).Equals(new(Stein)) &&
+			EvalPoly([]*Stein{a}, x).Equals(a) &&
+			EvalPoly([]*Stein{a, b}, x).Equals(linear)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// polyEqual returns true if p and q have the same coefficients, ignoring
+// trailing zeros.
+func polyEqual(p, q SteinPoly) bool {
+	p, q = p.trim(), q.trim()
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if !p[i].Equals(q[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSteinPolyMulDistributive(t *testing.T) {
+	f := func(p, q, r SteinPoly) bool {
+		// t.Logf("p = %v, q = %v, r = %v", p, q, r)
+		l := p.Mul(q.Add(r))
+		s := p.Mul(q).Add(p.Mul(r))
+		return polyEqual(l, s)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSteinPolyMulDegree(t *testing.T) {
+	f := func(p, q SteinPoly) bool {
+		// t.Logf("p = %v, q = %v", p, q)
+		if p.Degree() < 0 || q.Degree() < 0 {
+			return p.Mul(q).Degree() == -1
+		}
+		return p.Mul(q).Degree() == p.Degree()+q.Degree()
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSteinPolyEval(t *testing.T) {
+	f := func(p, q SteinPoly, x *Stein) bool {
+		// t.Logf("p = %v, q = %v, x = %v", p, q, x)
+		prod := new(Stein).Mul(p.Eval(x), q.Eval(x))
+		sum := new(Stein).Add(p.Eval(x), q.Eval(x))
+		return p.Eval(x).Equals(EvalPoly(p, x)) &&
+			p.Mul(q).Eval(x).Equals(prod) && p.Add(q).Eval(x).Equals(sum)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSteinPolyTrim(t *testing.T) {
+	zero := new(Stein)
+	p := SteinPoly{New(big.NewInt(1), big.NewInt(2)), zero, zero}
+	if got := p.Degree(); got != 0 {
+		t.Errorf("%v.Degree() = %d, want 0", p, got)
+	}
+	if got := (SteinPoly{zero}).Degree(); got != -1 {
+		t.Errorf("Degree of zero polynomial = %d, want -1", got)
+	}
+	neg := SteinPoly{new(Stein).Neg(p[0])}
+	if got := p.Add(neg); len(got) != 0 {
+		t.Errorf("%v.Add(%v) = %v, want empty", p, neg, got)
+	}
+}
+
+func TestSteinPolyQuoRem(t *testing.T) {
+	f := func(p, d SteinPoly) bool {
+		// t.Logf("p = %v, d = %v", p, d)
+		d = append(d.trim(), Omega())
+		q, r, err := p.QuoRem(d)
+		if err != nil {
+			return false
+		}
+		return polyEqual(q.Mul(d).Add(r), p) && r.Degree() < d.Degree()
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSteinPolyQuoRemExact(t *testing.T) {
+	f := func(q, d SteinPoly) bool {
+		// t.Logf("q = %v, d = %v", q, d)
+		d = append(d.trim(), New(big.NewInt(-1), big.NewInt(0)))
+		gotQ, gotR, err := q.Mul(d).QuoRem(d)
+		return err == nil && polyEqual(gotQ, q) && gotR.Degree() == -1
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSteinPolyQuoRemError(t *testing.T) {
+	p := SteinPoly{New(big.NewInt(1), big.NewInt(0)), New(big.NewInt(4), big.NewInt(2))}
+	d := SteinPoly{New(big.NewInt(1), big.NewInt(1)), New(big.NewInt(2), big.NewInt(0))}
+	if _, _, err := p.QuoRem(d); err == nil {
+		t.Errorf("%v.QuoRem(%v) succeeded, want error", p, d)
+	}
+	if _, _, err := p.QuoRem(SteinPoly{new(Stein)}); err == nil {
+		t.Errorf("%v.QuoRem(0) succeeded, want error", p)
+	}
+}
+
+func TestSteinPolyContent(t *testing.T) {
+	f := func(p SteinPoly, c *Stein) bool {
+		// t.Logf("p = %v, c = %v", p, c)
+		got := p.Mul(SteinPoly{c}).Content()
+		want := new(Stein).Mul(c, p.Content())
+		return got.IsAssociate(want)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	if got := (SteinPoly{}).Content(); !got.Equals(new(Stein)) {
+		t.Errorf("content of the zero polynomial = %v, want zero", got)
+	}
+}
+
+func TestSteinPolyPrimitive(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	f := func(p SteinPoly) bool {
+		// t.Logf("p = %v", p)
+		prim := p.Primitive()
+		if p.Degree() < 0 {
+			return len(prim) == 0
+		}
+		return prim.Content().Equals(one) && polyEqual(prim.Mul(SteinPoly{p.Content()}), p)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	// The content of 6 + (6+6ω)x is 6, and its primitive part is 1 + (1+ω)x.
+	p := SteinPoly{New(big.NewInt(6), big.NewInt(0)), New(big.NewInt(6), big.NewInt(6))}
+	want := SteinPoly{one, New(big.NewInt(1), big.NewInt(1))}
+	if got := p.Content(); !got.Equals(New(big.NewInt(6), big.NewInt(0))) {
+		t.Errorf("%v.Content() = %v, want (6+0ω)", p, got)
+	}
+	if got := p.Primitive(); !polyEqual(got, want) {
+		t.Errorf("%v.Primitive() = %v, want %v", p, got, want)
+	}
+}
+
+// smallPoly returns the polynomial with the coefficients a+bω for the pairs
+// in cs, which keeps the coefficients of products small.
+func smallPoly(cs []int8) SteinPoly {
+	p := make(SteinPoly, len(cs)/2)
+	for i := range p {
+		p[i] = New(big.NewInt(int64(cs[2*i])), big.NewInt(int64(cs[2*i+1])))
+	}
+	return p
+}
+
+// dividesPoly returns true if the nonzero polynomial d divides p over ℚ(ω).
+func dividesPoly(d, p SteinPoly) bool {
+	return len(ratPolyRem(ratPoly(p), ratPoly(d))) == 0
+}
+
+func TestPolyGCD(t *testing.T) {
+	f := func(a, b, c [4]int8) bool {
+		// t.Logf("a = %v, b = %v, c = %v", a, b, c)
+		x, y, z := smallPoly(a[:]), smallPoly(b[:]), smallPoly(c[:])
+		p, q := x.Mul(z), y.Mul(z)
+		g := PolyGCD(p, q)
+		if p.Degree() < 0 && q.Degree() < 0 {
+			return len(g) == 0
+		}
+		if len(g) == 0 || !g.Content().Equals(New(big.NewInt(1), big.NewInt(0))) {
+			return false
+		}
+		lead := g[len(g)-1]
+		if !new(Stein).Abs(lead).Equals(lead) {
+			return false
+		}
+		// g divides p and q, and the common factor z divides g.
+		return dividesPoly(g, p) && dividesPoly(g, q) &&
+			(z.Degree() < 0 || dividesPoly(z, g))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPolyGCDCoprime(t *testing.T) {
+	one := SteinPoly{New(big.NewInt(1), big.NewInt(0))}
+	f := func(r, s *Stein) bool {
+		// t.Logf("r = %v, s = %v", r, s)
+		if r.Equals(s) {
+			return true
+		}
+		// x - r and x - s have no common root.
+		p := SteinPoly{new(Stein).Neg(r), one[0]}
+		q := SteinPoly{new(Stein).Neg(s), one[0]}
+		return polyEqual(PolyGCD(p, q), one) && polyEqual(PolyGCD(p.Mul(p), q), one)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPolyGCDExample(t *testing.T) {
+	// (x - ω)(x + 2) and 3(x - ω)(x - 1) have the gcd x - ω.
+	omega := Omega()
+	xw := SteinPoly{new(Stein).Neg(omega), New(big.NewInt(1), big.NewInt(0))}
+	p := xw.Mul(SteinPoly{New(big.NewInt(2), big.NewInt(0)), New(big.NewInt(1), big.NewInt(0))})
+	q := xw.Mul(SteinPoly{New(big.NewInt(-3), big.NewInt(0)), New(big.NewInt(3), big.NewInt(0))})
+	if got := PolyGCD(p, q); !polyEqual(got, xw) {
+		t.Errorf("PolyGCD(%v, %v) = %v, want %v", p, q, got, xw)
+	}
+}
+
+func TestPolyGCDPrimitive(t *testing.T) {
+	// 2x and 2 have the common divisor 2, but the gcd is primitive.
+	one := New(big.NewInt(1), big.NewInt(0))
+	two := New(big.NewInt(2), big.NewInt(0))
+	p, q := SteinPoly{new(Stein), two}, SteinPoly{two}
+	if got := PolyGCD(p, q); !polyEqual(got, SteinPoly{one}) {
+		t.Errorf("PolyGCD(%v, %v) = %v, want [%v]", p, q, got, one)
+	}
+	f := func(a, b [4]int8, c, d int8) bool {
+		// t.Logf("a = %v, b = %v, c = %v, d = %v", a, b, c, d)
+		s := New(big.NewInt(int64(c)), big.NewInt(int64(d)))
+		x, y := smallPoly(a[:]).Mul(SteinPoly{s}), smallPoly(b[:]).Mul(SteinPoly{s})
+		g := PolyGCD(x, y)
+		if x.Degree() < 0 && y.Degree() < 0 {
+			return len(g) == 0
+		}
+		return g.Content().Equals(one) && polyEqual(g, PolyGCD(smallPoly(a[:]), smallPoly(b[:])))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
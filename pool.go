@@ -0,0 +1,49 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"sync"
+)
+
+// intPool holds scratch big.Int values for the arithmetic hot paths. A value
+// is taken and returned within a single call, so it is never shared between
+// calls or goroutines.
+var intPool = sync.Pool{
+	New: func() interface{} {
+		return new(big.Int)
+	},
+}
+
+// getInt returns a scratch big.Int from intPool. Its value is unspecified.
+func getInt() *big.Int {
+	return intPool.Get().(*big.Int)
+}
+
+// putInt returns scratch big.Int values to intPool.
+func putInt(xs ...*big.Int) {
+	for _, x := range xs {
+		intPool.Put(x)
+	}
+}
+
+// mul sets zl+zrω equal to the product of a+bω and c+dω. The outputs may
+// alias any of the inputs.
+func mul(zl, zr, a, b, c, d *big.Int) {
+	// The ω component ad+bc-bd is found from (a+b)(c+d) - ac - 2bd, so only
+	// three big.Int products are needed.
+	s, t, u := getInt(), getInt(), getInt()
+	ac, bd := getInt(), getInt()
+	s.Add(a, b)
+	t.Add(c, d)
+	u.Mul(s, t)
+	ac.Mul(a, c)
+	bd.Mul(b, d)
+	u.Sub(u, ac)
+	u.Sub(u, bd)
+	zl.Sub(ac, bd)
+	zr.Sub(u, bd)
+	putInt(s, t, u, ac, bd)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"math/rand"
+)
+
+// sieve returns the rational primes up to n, using the sieve of
+// Eratosthenes.
+func sieve(n int64) []int64 {
+	if n < 2 {
+		return nil
+	}
+	composite := make([]bool, n+1)
+	var primes []int64
+	for p := int64(2); p <= n; p++ {
+		if composite[p] {
+			continue
+		}
+		primes = append(primes, p)
+		for m := p * p; m <= n; m += p {
+			composite[m] = true
+		}
+	}
+	return primes
+}
+
+// RandomPrime returns an Eisenstein prime with quadrance at most maxNorm,
+// chosen uniformly from the primes up to associates and normalized as in
+// Abs. The choice depends only on the state of rnd. RandomPrime returns nil
+// if maxNorm is less than 3, the smallest quadrance of a prime.
+func RandomPrime(rnd *rand.Rand, maxNorm int64) *Stein {
+	// Only the chosen rational prime is lifted to ℤ[ω], so each rational
+	// prime p = 1 mod 3 takes two slots, one for each of its primes.
+	type slot struct {
+		p int64
+		i int
+	}
+	var slots []slot
+	for _, p := range sieve(maxNorm) {
+		switch {
+		case p%3 == 1:
+			slots = append(slots, slot{p, 0}, slot{p, 1})
+		case p%3 == 0 || p <= maxNorm/p:
+			slots = append(slots, slot{p, 0})
+		}
+	}
+	if len(slots) == 0 {
+		return nil
+	}
+	s := slots[rnd.Intn(len(slots))]
+	return primesOver(big.NewInt(s.p))[s.i]
+}
+
+// NextPrime sets z to the Eisenstein prime with the smallest quadrance
+// greater than the quadrance of y, and returns z. The prime is normalized as
+// in Abs, and of the two such primes over a rational prime p = 1 mod 3, the
+// one that is smaller by its components is chosen.
+func (z *Stein) NextPrime(y *Stein) *Stein {
+	n := y.Quad()
+	q, mod := new(big.Int), new(big.Int)
+	three := big.NewInt(3)
+	for {
+		n.Add(n, big.NewInt(1))
+		if n.ProbablyPrime(20) {
+			if mod.Mod(n, three).Int64() != 2 {
+				return z.Set(primesOver(n)[0])
+			}
+			continue
+		}
+		if q.Sqrt(n); new(big.Int).Mul(q, q).Cmp(n) == 0 &&
+			mod.Mod(q, three).Int64() == 2 && q.ProbablyPrime(20) {
+			return z.Set(New(q, big.NewInt(0)))
+		}
+	}
+}
+
+// PrimePiNorm returns the number of Eisenstein primes up to associates with
+// quadrance at most bound. Each rational prime p = 1 mod 3 up to bound
+// contributes two primes, 3 contributes one, and each rational prime
+// p = 2 mod 3 with p² up to bound contributes one.
+func PrimePiNorm(bound int64) int {
+	count := 0
+	for _, p := range sieve(bound) {
+		switch {
+		case p%3 == 1:
+			count += 2
+		case p%3 == 0 || p <= bound/p:
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestRandomPrime(t *testing.T) {
+	maxNorm := int64(10000)
+	r1, r2 := rand.New(rand.NewSource(7)), rand.New(rand.NewSource(7))
+	for i := 0; i < 100; i++ {
+		p, q := RandomPrime(r1, maxNorm), RandomPrime(r2, maxNorm)
+		if !p.IsEisensteinPrime() {
+			t.Errorf("RandomPrime returned %v, which is not prime", p)
+		}
+		if p.Quad().Cmp(big.NewInt(maxNorm)) > 0 {
+			t.Errorf("RandomPrime returned %v, with quadrance above %d", p, maxNorm)
+		}
+		if !p.Equals(q) {
+			t.Errorf("RandomPrime gave %v and %v from equal seeds", p, q)
+		}
+	}
+	seen := make(map[string]bool)
+	for i := 0; i < 200; i++ {
+		seen[RandomPrime(r1, 50).String()] = true
+	}
+	// The primes up to associates with quadrance at most 50 lie over 2, 3,
+	// 5, 7, 13, 19, 31, 37, and 43.
+	if len(seen) != 15 {
+		t.Errorf("RandomPrime(rnd, 50) gave %d distinct primes, want 15", len(seen))
+	}
+	if p := RandomPrime(r1, 2); p != nil {
+		t.Errorf("RandomPrime(rnd, 2) = %v, want nil", p)
+	}
+}
+
+func TestNextPrime(t *testing.T) {
+	// The quadrances of the primes are 3, 4, the primes p = 1 mod 3, and
+	// the squares of the primes p = 2 mod 3.
+	norms := []int64{3, 4, 7, 13, 19, 25, 31, 37, 43, 61, 67, 73, 79, 97, 103, 109, 121}
+	z := new(Stein)
+	for _, n := range norms {
+		z.NextPrime(z)
+		if !z.IsEisensteinPrime() {
+			t.Errorf("NextPrime gave %v, which is not prime", z)
+		}
+		if got := z.Quad().Int64(); got != n {
+			t.Errorf("NextPrime gave %v with quadrance %d, want %d", z, got, n)
+		}
+		if !new(Stein).Abs(z).Equals(z) {
+			t.Errorf("NextPrime gave %v, which is not normalized", z)
+		}
+	}
+	want := New(big.NewInt(3), big.NewInt(1))
+	if got := new(Stein).NextPrime(New(big.NewInt(2), big.NewInt(0))); !got.Equals(want) {
+		t.Errorf("NextPrime(2) = %v, want %v", got, want)
+	}
+}
+
+func TestPrimePiNorm(t *testing.T) {
+	var tests = []struct {
+		bound int64
+		want  int
+	}{
+		{-1, 0}, {2, 0}, {3, 1}, {4, 2}, {6, 2}, {7, 4}, {13, 6}, {25, 9}, {50, 15},
+	}
+	for _, test := range tests {
+		if got := PrimePiNorm(test.bound); got != test.want {
+			t.Errorf("PrimePiNorm(%d) = %d, want %d", test.bound, got, test.want)
+		}
+	}
+}
+
+func TestPrimePiNormBruteForce(t *testing.T) {
+	count := make(map[int64]int)
+	for _, z := range SteinsUpToNorm(500) {
+		if z.IsEisensteinPrime() {
+			count[z.Quad().Int64()]++
+		}
+	}
+	want, prev := 0, 0
+	for n := int64(0); n <= 500; n++ {
+		want += count[n] / 6
+		got := PrimePiNorm(n)
+		if got != want {
+			t.Errorf("PrimePiNorm(%d) = %d, want %d", n, got, want)
+		}
+		if got < prev {
+			t.Errorf("PrimePiNorm(%d) = %d < PrimePiNorm(%d) = %d", n, got, n-1, prev)
+		}
+		prev = got
+	}
+}
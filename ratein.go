@@ -0,0 +1,199 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"strings"
+)
+
+// A Ratein represents an arbitrary-precision element of ℚ(ω), the field of
+// fractions of the Eisenstein integers. Each component is a big.Rat, which is
+// always kept in lowest terms, so a Ratein value is always in canonical form
+// and equal values have identical representations. NumDenom gives the value
+// as a single fraction.
+type Ratein struct {
+	l, r big.Rat
+}
+
+// NewRatein returns a pointer to the Ratein value a+bω.
+func NewRatein(a, b *big.Rat) *Ratein {
+	z := new(Ratein)
+	z.l.Set(a)
+	z.r.Set(b)
+	return z
+}
+
+// FromStein returns a pointer to the Ratein value equal to y.
+func FromStein(y *Stein) *Ratein {
+	z := new(Ratein)
+	z.l.SetInt(&y.l)
+	z.r.SetInt(&y.r)
+	return z
+}
+
+// Rats returns the pointers to the two rational components of z.
+func (z *Ratein) Rats() (*big.Rat, *big.Rat) {
+	return &z.l, &z.r
+}
+
+// String returns the string version of a Ratein value.
+//
+// If z corresponds to a + bω, then the string is "(a+bω)", where each
+// component is written as a fraction unless it is an integer.
+func (z *Ratein) String() string {
+	a := make([]string, 5)
+	a[0] = "("
+	a[1] = z.l.RatString()
+	if z.r.Sign() == -1 {
+		a[2] = z.r.RatString()
+	} else {
+		a[2] = fmt.Sprintf("+%v", z.r.RatString())
+	}
+	a[3] = "ω"
+	a[4] = ")"
+	return strings.Join(a, "")
+}
+
+// Equals returns true if y and z are equal.
+func (z *Ratein) Equals(y *Ratein) bool {
+	if z.l.Cmp(&y.l) != 0 || z.r.Cmp(&y.r) != 0 {
+		return false
+	}
+	return true
+}
+
+// Set sets z equal to y, and returns z.
+func (z *Ratein) Set(y *Ratein) *Ratein {
+	z.l.Set(&y.l)
+	z.r.Set(&y.r)
+	return z
+}
+
+// IsStein returns true if z is an Eisenstein integer, that is, if both of
+// its components are integers.
+func (z *Ratein) IsStein() bool {
+	return z.l.IsInt() && z.r.IsInt()
+}
+
+// IsInteger returns true if z is a rational integer, that is, if its ω
+// component is zero and its other component is an integer.
+func (z *Ratein) IsInteger() bool {
+	return z.r.Sign() == 0 && z.l.IsInt()
+}
+
+// Signs returns the signs of the two rational components of z. Each sign is
+// -1, 0, or +1, as in big.Rat.Sign.
+func (z *Ratein) Signs() (int, int) {
+	return z.l.Sign(), z.r.Sign()
+}
+
+// NumDenom returns the Eisenstein integer num and the positive rational
+// integer den such that z = num/den, in lowest terms: den is the least
+// common denominator of the components of z, so the content of num has no
+// factor in common with den. Equal values give equal pairs, so this is the
+// canonical form of z as a single fraction.
+func (z *Ratein) NumDenom() (num *Stein, den *big.Int) {
+	g := new(big.Int).GCD(nil, nil, z.l.Denom(), z.r.Denom())
+	den = new(big.Int).Quo(z.l.Denom(), g)
+	den.Mul(den, z.r.Denom())
+	num = new(Stein)
+	num.l.Mul(z.l.Num(), new(big.Int).Quo(den, z.l.Denom()))
+	num.r.Mul(z.r.Num(), new(big.Int).Quo(den, z.r.Denom()))
+	return num, den
+}
+
+// Reduce sets z to the canonical form num/den given by NumDenom, and returns
+// z. Each big.Rat component is already kept in lowest terms, so this leaves z
+// unchanged; Reduce is provided so that the canonical form can be asked for
+// explicitly, and equal values have identical representations after it as
+// before.
+func (z *Ratein) Reduce() *Ratein {
+	num, den := z.NumDenom()
+	z.l.SetFrac(&num.l, den)
+	z.r.SetFrac(&num.r, den)
+	return z
+}
+
+// Neg sets z equal to the negative of y, and returns z.
+func (z *Ratein) Neg(y *Ratein) *Ratein {
+	z.l.Neg(&y.l)
+	z.r.Neg(&y.r)
+	return z
+}
+
+// Conj sets z equal to the conjugate of y, and returns z.
+func (z *Ratein) Conj(y *Ratein) *Ratein {
+	z.l.Sub(&y.l, &y.r)
+	z.r.Neg(&y.r)
+	return z
+}
+
+// Add sets z equal to the sum of x and y, and returns z.
+func (z *Ratein) Add(x, y *Ratein) *Ratein {
+	z.l.Add(&x.l, &y.l)
+	z.r.Add(&x.r, &y.r)
+	return z
+}
+
+// Sub sets z equal to the difference of x and y, and returns z.
+func (z *Ratein) Sub(x, y *Ratein) *Ratein {
+	z.l.Sub(&x.l, &y.l)
+	z.r.Sub(&x.r, &y.r)
+	return z
+}
+
+// Mul sets z equal to the product of x and y, and returns z.
+//
+// The multiplication rule is the same as for Stein values.
+func (z *Ratein) Mul(x, y *Ratein) *Ratein {
+	ac := new(big.Rat).Mul(&x.l, &y.l)
+	bd := new(big.Rat).Mul(&x.r, &y.r)
+	ad := new(big.Rat).Mul(&x.l, &y.r)
+	bc := new(big.Rat).Mul(&x.r, &y.l)
+	z.l.Sub(ac, bd)
+	z.r.Add(ad, bc)
+	z.r.Sub(&z.r, bd)
+	return z
+}
+
+// Quad returns the quadrance of z. If z = a+bω, then the quadrance is
+// a² + b² - ab. This is always non-negative.
+func (z *Ratein) Quad() *big.Rat {
+	quad := new(big.Rat)
+	temp := new(big.Rat)
+	quad.Add(
+		quad.Mul(&z.l, &z.l),
+		temp.Mul(&z.r, &z.r),
+	)
+	quad.Sub(
+		quad,
+		temp.Mul(&z.l, &z.r),
+	)
+	return quad
+}
+
+// Quo sets z equal to the quotient of x and y, and returns z. Unlike
+// Stein.Quo, the division is exact. If y is zero, a division-by-zero
+// run-time panic occurs.
+func (z *Ratein) Quo(x, y *Ratein) *Ratein {
+	quad := y.Quad()
+	conj := new(Ratein).Conj(y)
+	z.Mul(x, conj)
+	z.l.Quo(&z.l, quad)
+	z.r.Quo(&z.r, quad)
+	return z
+}
+
+// Generate a random Ratein value for quick.Check testing.
+func (z *Ratein) Generate(rand *rand.Rand, size int) reflect.Value {
+	randomRatein := &Ratein{
+		*big.NewRat(rand.Int63(), 1+rand.Int63n(1<<31)),
+		*big.NewRat(rand.Int63(), 1+rand.Int63n(1<<31)),
+	}
+	return reflect.ValueOf(randomRatein)
+}
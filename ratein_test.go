@@ -0,0 +1,203 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestRateinAddCommutative(t *testing.T) {
+	f := func(x, y *Ratein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		l := new(Ratein).Add(x, y)
+		r := new(Ratein).Add(y, x)
+		return l.Equals(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRateinAddAssociative(t *testing.T) {
+	f := func(x, y, z *Ratein) bool {
+		// t.Logf("x = %v, y = %v, z = %v", x, y, z)
+		l, r := new(Ratein), new(Ratein)
+		l.Add(l.Add(x, y), z)
+		r.Add(x, r.Add(y, z))
+		return l.Equals(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRateinMulCommutative(t *testing.T) {
+	f := func(x, y *Ratein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		l := new(Ratein).Mul(x, y)
+		r := new(Ratein).Mul(y, x)
+		return l.Equals(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRateinMulAssociative(t *testing.T) {
+	f := func(x, y, z *Ratein) bool {
+		// t.Logf("x = %v, y = %v, z = %v", x, y, z)
+		l, r := new(Ratein), new(Ratein)
+		l.Mul(l.Mul(x, y), z)
+		r.Mul(x, r.Mul(y, z))
+		return l.Equals(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRateinSubAntiCommutative(t *testing.T) {
+	f := func(x, y *Ratein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		l, r := new(Ratein), new(Ratein)
+		l.Sub(x, y)
+		r.Sub(y, x)
+		r.Neg(r)
+		return l.Equals(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRateinConjInvolutive(t *testing.T) {
+	f := func(x *Ratein) bool {
+		// t.Logf("x = %v", x)
+		l := new(Ratein)
+		l.Conj(l.Conj(x))
+		return l.Equals(x)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRateinMulQuoInverse(t *testing.T) {
+	f := func(x, y *Ratein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		l := new(Ratein).Quo(x, y)
+		l.Mul(l, y)
+		return l.Equals(x)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRateinQuadMultiplicative(t *testing.T) {
+	f := func(x, y *Ratein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		l := new(Ratein).Mul(x, y).Quad()
+		r := new(big.Rat).Mul(x.Quad(), y.Quad())
+		return l.Cmp(r) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFromStein(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		l := FromStein(new(Stein).Mul(x, y))
+		r := new(Ratein).Mul(FromStein(x), FromStein(y))
+		return l.Equals(r) && l.IsStein()
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRateinIsStein(t *testing.T) {
+	x := NewRatein(big.NewRat(4, 2), big.NewRat(-3, 1))
+	if !x.IsStein() {
+		t.Errorf("%v.IsStein() = false, want true", x)
+	}
+	y := NewRatein(big.NewRat(1, 2), big.NewRat(0, 1))
+	if y.IsStein() {
+		t.Errorf("%v.IsStein() = true, want false", y)
+	}
+}
+
+func TestRateinNumDenom(t *testing.T) {
+	f := func(x *Ratein, s *Stein) bool {
+		num, den := x.NumDenom()
+		if den.Sign() <= 0 || new(big.Int).GCD(nil, nil, num.Content(), den).Cmp(big.NewInt(1)) != 0 {
+			return false
+		}
+		back := FromStein(num)
+		back.Quo(back, NewRatein(new(big.Rat).SetInt(den), new(big.Rat)))
+		if !back.Equals(x) {
+			return false
+		}
+		// The same value built as a ratio of larger Eisenstein integers
+		// gives the same pair.
+		if s.Quad().Sign() == 0 {
+			return true
+		}
+		y := new(Ratein).Mul(x, FromStein(s))
+		y.Quo(y, FromStein(s))
+		num2, den2 := y.NumDenom()
+		return y.Equals(x) && num2.Equals(num) && den2.Cmp(den) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRateinReduce(t *testing.T) {
+	// (6+4ω)/4 and (9+6ω)/6 are both (3+2ω)/2.
+	x := FromStein(New(big.NewInt(6), big.NewInt(4)))
+	x.Quo(x, FromStein(New(big.NewInt(4), big.NewInt(0))))
+	y := FromStein(New(big.NewInt(9), big.NewInt(6)))
+	y.Quo(y, FromStein(New(big.NewInt(6), big.NewInt(0))))
+	want := new(Ratein).Set(x)
+	if got := x.Reduce(); got != x || !x.Equals(want) {
+		t.Errorf("Reduce() = %v, want the receiver with value %v", got, want)
+	}
+	y.Reduce()
+	xl, xr := x.Rats()
+	yl, yr := y.Rats()
+	if xl.Num().Cmp(yl.Num()) != 0 || xl.Denom().Cmp(yl.Denom()) != 0 ||
+		xr.Num().Cmp(yr.Num()) != 0 || xr.Denom().Cmp(yr.Denom()) != 0 {
+		t.Errorf("Reduce gives %v and %v, want identical representations", x, y)
+	}
+	num, den := x.NumDenom()
+	if !num.Equals(New(big.NewInt(3), big.NewInt(2))) || den.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("%v.NumDenom() = %v, %v, want (3+2ω), 2", x, num, den)
+	}
+}
+
+func TestRateinIsIntegerSigns(t *testing.T) {
+	var tests = []struct {
+		z       *Ratein
+		integer bool
+		sa, sb  int
+	}{
+		{new(Ratein), true, 0, 0},
+		{NewRatein(big.NewRat(-4, 2), new(big.Rat)), true, -1, 0},
+		{NewRatein(big.NewRat(1, 2), new(big.Rat)), false, 1, 0},
+		{NewRatein(big.NewRat(3, 1), big.NewRat(-1, 1)), false, 1, -1},
+	}
+	for _, test := range tests {
+		if got := test.z.IsInteger(); got != test.integer {
+			t.Errorf("%v.IsInteger() = %v, want %v", test.z, got, test.integer)
+		}
+		if sa, sb := test.z.Signs(); sa != test.sa || sb != test.sb {
+			t.Errorf("%v.Signs() = %d, %d, want %d, %d", test.z, sa, sb, test.sa, test.sb)
+		}
+	}
+}
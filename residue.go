@@ -0,0 +1,333 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+)
+
+var (
+	errNotInvertible = errors.New("eisen: not invertible modulo the modulus")
+	errNoSolution    = errors.New("eisen: no solution")
+	errTooLarge      = errors.New("eisen: modulus too large")
+	errLength        = errors.New("eisen: mismatched lengths")
+	errNotCoprime    = errors.New("eisen: moduli are not coprime")
+)
+
+// Residues returns a complete set of representatives of the residue ring
+// ℤ[ω]/(z), each reduced by Rem. There are as many as the quadrance of z,
+// and only zero for a unit. Residues returns an error if z is zero.
+func (z *Stein) Residues() ([]*Stein, error) {
+	quad := z.Quad()
+	if quad.Sign() == 0 {
+		return nil, errZero
+	}
+	// The ideal (z) is spanned over ℤ by z = a+bω and zω = -b+(a-b)ω. The
+	// ω components of the ideal are the multiples of c = gcd(a, b), and the
+	// integers in it are the multiples of N/c, so the values x+yω with
+	// 0 <= x < N/c and 0 <= y < c are pairwise incongruent.
+	c := new(big.Int).GCD(nil, nil, new(big.Int).Abs(&z.l), new(big.Int).Abs(&z.r))
+	m := new(big.Int).Quo(quad, c)
+	residues := make([]*Stein, 0, quad.Int64())
+	one := big.NewInt(1)
+	for y := new(big.Int); y.Cmp(c) < 0; y.Add(y, one) {
+		for x := new(big.Int); x.Cmp(m) < 0; x.Add(x, one) {
+			residues = append(residues, new(Stein).Rem(New(x, y), z))
+		}
+	}
+	return residues, nil
+}
+
+// QuotientRingSize returns the number of elements of the residue ring
+// ℤ[ω]/(z), which is the quadrance of z. It is also the absolute value of the
+// determinant of Matrix, since the ideal (z) is the image of ℤ[ω] under
+// multiplication by z. The ring has one element if z is a unit.
+// QuotientRingSize returns an error if z is zero, since the ring is then
+// infinite.
+func (z *Stein) QuotientRingSize() (*big.Int, error) {
+	quad := z.Quad()
+	if quad.Sign() == 0 {
+		return nil, errZero
+	}
+	return quad, nil
+}
+
+// ExpMod sets z equal to x raised to the power y, reduced modulo m by Rem,
+// and returns z. If m is nil or zero, no reduction is done. If y <= 0, the
+// result is one.
+func (z *Stein) ExpMod(x *Stein, y *big.Int, m *Stein) *Stein {
+	reduce := m != nil && (m.l.Sign() != 0 || m.r.Sign() != 0)
+	base := new(Stein).Set(x)
+	if reduce {
+		base.Rem(base, m)
+	}
+	result := New(big.NewInt(1), big.NewInt(0))
+	if y.Sign() > 0 {
+		for i := y.BitLen() - 1; i >= 0; i-- {
+			result.Mul(result, result)
+			if y.Bit(i) == 1 {
+				result.Mul(result, base)
+			}
+			if reduce {
+				result.Rem(result, m)
+			}
+		}
+	}
+	return z.Set(result)
+}
+
+// CongruentTo returns true if z is congruent to y modulo m, that is, if m
+// divides z - y. Since zero divides only zero, congruence modulo zero is
+// equality.
+func (z *Stein) CongruentTo(y, m *Stein) bool {
+	return m.Divides(new(Stein).Sub(z, y))
+}
+
+// MulOrder returns the multiplicative order of z in the unit group of the
+// residue ring ℤ[ω]/(m), that is, the least k > 0 such that z raised to the
+// power k is congruent to one modulo m. The order divides EulerPhi(m), and it
+// is found by removing prime factors from EulerPhi(m) while the power stays
+// congruent to one. MulOrder returns an error if m is zero or if z and m
+// have a common factor that is not a unit.
+func (z *Stein) MulOrder(m *Stein) (*big.Int, error) {
+	if m.l.Sign() == 0 && m.r.Sign() == 0 {
+		return nil, errZero
+	}
+	one := New(big.NewInt(1), big.NewInt(0))
+	if !new(Stein).GCD(nil, nil, z, m).Equals(one) {
+		return nil, errNotInvertible
+	}
+	order := m.EulerPhi()
+	q, d := new(big.Int), new(Stein)
+	for _, pp := range factorInt(new(big.Int).Set(order)) {
+		for i := 0; i < pp.k; i++ {
+			q.Quo(order, pp.p)
+			if !m.Divides(d.Sub(d.ExpMod(z, q, m), one)) {
+				break
+			}
+			order.Set(q)
+		}
+	}
+	return order, nil
+}
+
+// UnitGroupStructure returns the invariant factors d₁ | d₂ | ... | dᵣ of the
+// unit group of the residue ring ℤ[ω]/(z), which is isomorphic to the
+// product of the cyclic groups of orders dᵢ. Their product is EulerPhi(z),
+// and there are none if z is a unit. The factors are found from the
+// factorization of z, so z should have no large prime factors.
+// UnitGroupStructure returns an error if z is zero.
+//
+// For a prime power πᵏ over the rational prime p, with q the quadrance of π,
+// the unit group of ℤ[ω]/(πᵏ) is the cyclic group of order q - 1 times a
+// group of order qᵏ⁻¹. For k >= 2, the latter is cyclic of order pᵏ⁻¹ if
+// p = 1 mod 3, the product of two cyclic groups of order pᵏ⁻¹ if p = 2 mod 3
+// and p > 2, and of orders 2, 2ᵏ⁻², 2ᵏ⁻¹ if p = 2. For p = 3 the orders are
+// 3, 3ʲ, and 3ᵏ⁻²⁻ʲ with j = ⌊(k-2)/2⌋.
+func (z *Stein) UnitGroupStructure() ([]*big.Int, error) {
+	powers, err := z.factorPowers()
+	if err != nil {
+		return nil, err
+	}
+	// The elementary divisors, as powers of rational primes.
+	var divs []primePower
+	pow := func(p *big.Int, k int) {
+		if k > 0 {
+			divs = append(divs, primePower{p, k})
+		}
+	}
+	one, two, three := big.NewInt(1), big.NewInt(2), big.NewInt(3)
+	for _, pk := range powers {
+		q := pk.p.Quad()
+		divs = append(divs, factorInt(new(big.Int).Sub(q, one))...)
+		k := pk.k
+		switch p := &pk.p.l; {
+		case q.Cmp(three) == 0:
+			if k >= 2 {
+				j := (k - 2) / 2
+				pow(three, 1)
+				pow(three, j)
+				pow(three, k-2-j)
+			}
+		case pk.p.r.Sign() != 0:
+			pow(q, k-1)
+		case p.Cmp(two) == 0:
+			if k >= 2 {
+				pow(two, 1)
+				pow(two, k-2)
+				pow(two, k-1)
+			}
+		default:
+			pow(p, k-1)
+			pow(p, k-1)
+		}
+	}
+	// The i-th largest invariant factor is the product of the i-th largest
+	// elementary divisors for each prime.
+	exps := make(map[string][]int)
+	primes := make(map[string]*big.Int)
+	r := 0
+	for _, d := range divs {
+		key := d.p.String()
+		primes[key] = d.p
+		exps[key] = append(exps[key], d.k)
+		if n := len(exps[key]); n > r {
+			r = n
+		}
+	}
+	factors := make([]*big.Int, r)
+	for i := range factors {
+		factors[i] = big.NewInt(1)
+	}
+	for key, ks := range exps {
+		sort.Sort(sort.Reverse(sort.IntSlice(ks)))
+		for i, k := range ks {
+			f := factors[r-1-i]
+			f.Mul(f, new(big.Int).Exp(primes[key], big.NewInt(int64(k)), nil))
+		}
+	}
+	return factors, nil
+}
+
+// reduce sets z equal to the canonical representative of x modulo m, and
+// returns z. Unlike Rem, congruent values always give the same
+// representative, which is the one in the range used by Residues. The
+// modulus m must not be zero.
+func (z *Stein) reduce(x, m *Stein) *Stein {
+	// In Hermite normal form, the ideal (m) is spanned by N/c and u+cω,
+	// where c = gcd(a, b) is the gcd of the ω components of m = a+bω and
+	// mω = -b+(a-b)ω. If c = sb + t(a-b), then u = sa - tb.
+	s, t := new(big.Int), new(big.Int)
+	b := new(big.Int).Sub(&m.l, &m.r)
+	c := new(big.Int).GCD(s, t, new(big.Int).Abs(&m.r), new(big.Int).Abs(b))
+	if m.r.Sign() < 0 {
+		s.Neg(s)
+	}
+	if b.Sign() < 0 {
+		t.Neg(t)
+	}
+	u := new(big.Int).Mul(s, &m.l)
+	u.Sub(u, b.Mul(t, &m.r))
+	n := m.Quad()
+	n.Quo(n, c)
+	// Clear the ω component down to [0, c), then the integer one to [0, n).
+	q, r := new(big.Int).DivMod(&x.r, c, new(big.Int))
+	l := new(big.Int).Mul(q, u)
+	l.Sub(&x.l, l)
+	z.l.Mod(l, n)
+	z.r.Set(r)
+	return z
+}
+
+// ModInverse sets z equal to the inverse of g modulo n, reduced to the
+// representative used by Residues, and returns z. If g and n have a common
+// factor that is not a unit, or if n is zero, then g has no inverse, z is
+// unchanged, and the return value is nil.
+func (z *Stein) ModInverse(g, n *Stein) *Stein {
+	if n.l.Sign() == 0 && n.r.Sign() == 0 {
+		return nil
+	}
+	x := new(Stein)
+	if !new(Stein).GCD(x, nil, g, n).Equals(omegaPowers[0]) {
+		return nil
+	}
+	return z.reduce(x, n)
+}
+
+// SolveCongruence returns a solution x of the linear congruence
+// Mul(a, x) = b modulo n. If d is the greatest common divisor of a and n,
+// then the congruence has a solution exactly when d divides b, and the
+// solutions are unique modulo n/d; the one returned is reduced modulo n/d to
+// the representative used by Residues. SolveCongruence returns an error if n
+// is zero or if there is no solution.
+func SolveCongruence(a, b, n *Stein) (*Stein, error) {
+	if n.l.Sign() == 0 && n.r.Sign() == 0 {
+		return nil, errZero
+	}
+	x := new(Stein)
+	d := new(Stein).GCD(x, nil, a, n)
+	if !d.Divides(b) {
+		return nil, errNoSolution
+	}
+	// Since d = ax + ny, a(xb/d) = b modulo n.
+	x.Mul(x, new(Stein).Quo(b, d))
+	return x.reduce(x, new(Stein).Quo(n, d)), nil
+}
+
+// CRT returns the solution x of the system of congruences x = residues[i]
+// modulo moduli[i], for pairwise coprime moduli. The solution is unique
+// modulo the product of the moduli, and it is reduced to the representative
+// used by Residues. CRT returns an error if the slices have different
+// lengths, if a modulus is zero, or if two moduli have a common factor that
+// is not a unit.
+func CRT(residues, moduli []*Stein) (*Stein, error) {
+	if len(residues) != len(moduli) {
+		return nil, errLength
+	}
+	x, prod := new(Stein), New(big.NewInt(1), big.NewInt(0))
+	inv, t := new(Stein), new(Stein)
+	for i, m := range moduli {
+		if m.l.Sign() == 0 && m.r.Sign() == 0 {
+			return nil, errZero
+		}
+		if inv.ModInverse(prod, m) == nil {
+			return nil, errNotCoprime
+		}
+		// Lift x by a multiple of prod, which keeps the earlier congruences,
+		// so that it also satisfies x = residues[i] modulo m.
+		t.Sub(residues[i], x)
+		t.reduce(t.Mul(t, inv), m)
+		x.Add(x, t.Mul(t, prod))
+		prod.Mul(prod, m)
+	}
+	return x.reduce(x, prod), nil
+}
+
+// maxLogModulus is the largest quadrance of a modulus accepted by
+// DiscreteLog.
+const maxLogModulus = 1 << 32
+
+// DiscreteLog returns the least k >= 0 such that g raised to the power k is
+// congruent to h modulo m. It uses the baby-step giant-step method over the
+// residues modulo m, so it takes time and memory proportional to the square
+// root of EulerPhi(m). DiscreteLog returns an error if m is zero or has
+// quadrance above 2³², if g is not invertible modulo m, or if there is no
+// such k.
+func DiscreteLog(g, h, m *Stein) (*big.Int, error) {
+	n := m.Quad()
+	if n.Sign() == 0 {
+		return nil, errZero
+	}
+	if n.Cmp(big.NewInt(maxLogModulus)) > 0 {
+		return nil, errTooLarge
+	}
+	inv := new(Stein).ModInverse(g, m)
+	if inv == nil {
+		return nil, errNotInvertible
+	}
+	// Every power of g lies in the unit group, whose order is EulerPhi(m),
+	// so k = is + j with 0 <= i, j < s.
+	s := new(big.Int).Sqrt(m.EulerPhi()).Int64() + 1
+	baby := make(map[string]int64, s)
+	p := new(Stein).reduce(omegaPowers[0], m)
+	for j := int64(0); j < s; j++ {
+		key := p.String()
+		if _, ok := baby[key]; !ok {
+			baby[key] = j
+		}
+		p.reduce(p.Mul(p, g), m)
+	}
+	// Each giant step multiplies by the inverse of g raised to the power s.
+	giant := new(Stein).ExpMod(inv, big.NewInt(s), m)
+	p.reduce(h, m)
+	for i := int64(0); i < s; i++ {
+		if j, ok := baby[p.String()]; ok {
+			return big.NewInt(i*s + j), nil
+		}
+		p.reduce(p.Mul(p, giant), m)
+	}
+	return nil, errNoSolution
+}
@@ -0,0 +1,409 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestResidues(t *testing.T) {
+	for _, z := range SteinsUpToNorm(40)[1:] {
+		residues, err := z.Residues()
+		if err != nil {
+			t.Fatalf("%v.Residues() failed: %v", z, err)
+		}
+		if got, want := int64(len(residues)), z.Quad().Int64(); got != want {
+			t.Errorf("len(%v.Residues()) = %d, want %d", z, got, want)
+		}
+		diff := new(Stein)
+		for i, r := range residues {
+			for _, s := range residues[:i] {
+				if z.Divides(diff.Sub(r, s)) {
+					t.Errorf("%v.Residues() has congruent %v and %v", z, r, s)
+				}
+			}
+		}
+	}
+}
+
+func TestResiduesUnitAndZero(t *testing.T) {
+	residues, err := Omega().Residues()
+	if err != nil || len(residues) != 1 || !residues[0].Equals(new(Stein)) {
+		t.Errorf("Omega().Residues() = %v, %v, want [(0+0ω)]", residues, err)
+	}
+	if _, err := new(Stein).Residues(); err == nil {
+		t.Error("Residues of zero succeeded, want error")
+	}
+}
+
+func TestCongruentTo(t *testing.T) {
+	f := func(x, y, w *Stein, a, b int8) bool {
+		// t.Logf("x = %v, y = %v, w = %v, a = %v, b = %v", x, y, w, a, b)
+		m := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		if !x.CongruentTo(x, m) {
+			return false
+		}
+		shifted := new(Stein).Add(x, new(Stein).Mul(w, m))
+		if !shifted.CongruentTo(x, m) || !x.CongruentTo(shifted, m) {
+			return false
+		}
+		if x.CongruentTo(y, m) != y.CongruentTo(x, m) {
+			return false
+		}
+		if m.Quad().Sign() == 0 {
+			return x.CongruentTo(y, m) == x.Equals(y)
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCongruentToTransitive(t *testing.T) {
+	m := New(big.NewInt(3), big.NewInt(1))
+	xs := SteinsUpToNorm(30)
+	for _, x := range xs {
+		for _, y := range xs {
+			if !x.CongruentTo(y, m) {
+				continue
+			}
+			for _, z := range xs {
+				if y.CongruentTo(z, m) && !x.CongruentTo(z, m) {
+					t.Errorf("%v ≡ %v ≡ %v modulo %v, but CongruentTo(%v, %v) = false", x, y, z, m, x, z)
+				}
+			}
+		}
+	}
+}
+
+func TestMulOrder(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	f := func(x *Stein, a, b int8) bool {
+		// t.Logf("x = %v, a = %v, b = %v", x, a, b)
+		m := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		order, err := x.MulOrder(m)
+		if m.Quad().Sign() == 0 || !new(Stein).GCD(nil, nil, x, m).Equals(one) {
+			return err != nil
+		}
+		if err != nil || order.Sign() <= 0 {
+			return false
+		}
+		if new(big.Int).Rem(m.EulerPhi(), order).Sign() != 0 {
+			return false
+		}
+		d := new(Stein).ExpMod(x, order, m)
+		return m.Divides(d.Sub(d, one))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMulOrderBruteForce(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	m := New(big.NewInt(5), big.NewInt(2))
+	residues, _ := m.Residues()
+	p, d := new(Stein), new(Stein)
+	for _, x := range residues {
+		order, err := x.MulOrder(m)
+		if x.Equals(new(Stein)) {
+			if err == nil {
+				t.Errorf("%v.MulOrder(%v) succeeded, want error", x, m)
+			}
+			continue
+		}
+		want := int64(1)
+		for p.Rem(x, m); !m.Divides(d.Sub(p, one)); p.Rem(p.Mul(p, x), m) {
+			want++
+		}
+		if err != nil || order.Int64() != want {
+			t.Errorf("%v.MulOrder(%v) = %v, %v, want %d", x, m, order, err, want)
+		}
+	}
+}
+
+func TestUnitGroupStructurePrime(t *testing.T) {
+	for _, p := range SteinsUpToNorm(200) {
+		if !p.IsEisensteinPrime() {
+			continue
+		}
+		factors, err := p.UnitGroupStructure()
+		want := new(big.Int).Sub(p.Quad(), big.NewInt(1))
+		if err != nil || len(factors) != 1 || factors[0].Cmp(want) != 0 {
+			t.Errorf("%v.UnitGroupStructure() = %v, %v, want [%v]", p, factors, err, want)
+		}
+	}
+}
+
+func TestUnitGroupStructureExamples(t *testing.T) {
+	lambda := New(big.NewInt(2), big.NewInt(1))
+	var tests = []struct {
+		m    *Stein
+		want []int64
+	}{
+		{New(big.NewInt(1), big.NewInt(0)), []int64{}},
+		{New(big.NewInt(25), big.NewInt(0)), []int64{5, 120}},
+		{New(big.NewInt(32), big.NewInt(0)), []int64{2, 8, 48}},
+		{new(Stein).ExpMod(lambda, big.NewInt(7), nil), []int64{3, 9, 54}},
+		{New(big.NewInt(49), big.NewInt(0)), []int64{42, 42}},
+		{New(big.NewInt(6), big.NewInt(0)), []int64{3, 6}},
+	}
+	for _, test := range tests {
+		factors, err := test.m.UnitGroupStructure()
+		ok := err == nil && len(factors) == len(test.want)
+		for i := 0; ok && i < len(factors); i++ {
+			ok = factors[i].Int64() == test.want[i]
+		}
+		if !ok {
+			t.Errorf("%v.UnitGroupStructure() = %v, %v, want %v", test.m, factors, err, test.want)
+		}
+	}
+	if _, err := new(Stein).UnitGroupStructure(); err == nil {
+		t.Error("UnitGroupStructure of zero succeeded, want error")
+	}
+}
+
+func TestUnitGroupStructureBruteForce(t *testing.T) {
+	// In a product of cyclic groups of orders dᵢ, the number of solutions
+	// of xⁿ = 1 is the product of gcd(n, dᵢ), and these counts for the
+	// divisors n of the order determine the group.
+	one := New(big.NewInt(1), big.NewInt(0))
+	d := new(Stein)
+	for _, m := range SteinsUpToNorm(100) {
+		if m.Quad().Sign() == 0 || !new(Stein).Abs(m).Equals(m) {
+			continue
+		}
+		factors, err := m.UnitGroupStructure()
+		if err != nil {
+			t.Fatalf("%v.UnitGroupStructure() = %v", m, err)
+		}
+		for i := 1; i < len(factors); i++ {
+			if new(big.Int).Rem(factors[i], factors[i-1]).Sign() != 0 {
+				t.Errorf("%v.UnitGroupStructure() = %v, want a divisibility chain", m, factors)
+			}
+		}
+		residues, _ := m.Residues()
+		phi := m.EulerPhi().Int64()
+		for n := int64(1); n <= phi; n++ {
+			if phi%n != 0 {
+				continue
+			}
+			count := int64(0)
+			for _, r := range residues {
+				if m.Divides(d.Sub(d.ExpMod(r, big.NewInt(n), m), one)) {
+					count++
+				}
+			}
+			want := int64(1)
+			for _, f := range factors {
+				want *= new(big.Int).GCD(nil, nil, f, big.NewInt(n)).Int64()
+			}
+			if count != want {
+				t.Errorf("%v.UnitGroupStructure() = %v: %d solutions of x^%d = 1, want %d", m, factors, count, n, want)
+			}
+		}
+	}
+}
+
+func TestReduce(t *testing.T) {
+	f := func(x, y *Stein, a, b int8) bool {
+		// t.Logf("x = %v, y = %v, a = %v, b = %v", x, y, a, b)
+		m := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		if m.Quad().Sign() == 0 {
+			return true
+		}
+		r := new(Stein).reduce(x, m)
+		if !m.Divides(new(Stein).Sub(x, r)) {
+			return false
+		}
+		// A congruent value has the same representative.
+		w := new(Stein).Add(x, new(Stein).Mul(y, m))
+		return new(Stein).reduce(w, m).Equals(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReduceResidues(t *testing.T) {
+	for _, m := range SteinsUpToNorm(40)[1:] {
+		residues, _ := m.Residues()
+		seen := make(map[string]bool)
+		for _, r := range residues {
+			seen[new(Stein).reduce(r, m).String()] = true
+		}
+		if int64(len(seen)) != m.Quad().Int64() {
+			t.Errorf("reduce modulo %v gives %d classes, want %v", m, len(seen), m.Quad())
+		}
+	}
+}
+
+func TestDiscreteLog(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	moduli := []*Stein{
+		New(big.NewInt(5), big.NewInt(2)),  // prime over 19
+		New(big.NewInt(11), big.NewInt(0)), // inert prime
+		New(big.NewInt(7), big.NewInt(3)),  // prime over 37
+		New(big.NewInt(6), big.NewInt(0)),  // composite
+	}
+	f := func(g *Stein, e uint16, i uint8) bool {
+		// t.Logf("g = %v, e = %v, i = %v", g, e, i)
+		m := moduli[int(i)%len(moduli)]
+		if !new(Stein).GCD(nil, nil, g, m).Equals(one) {
+			_, err := DiscreteLog(g, one, m)
+			return err != nil
+		}
+		h := new(Stein).ExpMod(g, big.NewInt(int64(e)), m)
+		k, err := DiscreteLog(g, h, m)
+		if err != nil || k.Int64() > int64(e) {
+			return false
+		}
+		d := new(Stein).ExpMod(g, k, m)
+		return m.Divides(d.Sub(d, h))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDiscreteLogNoSolution(t *testing.T) {
+	// Modulo 7, the powers of 2 are 1, 2, and 4, so 3 is not among them.
+	m := New(big.NewInt(7), big.NewInt(0))
+	g, h := New(big.NewInt(2), big.NewInt(0)), New(big.NewInt(3), big.NewInt(0))
+	if k, err := DiscreteLog(g, h, m); err == nil {
+		t.Errorf("DiscreteLog(%v, %v, %v) = %v, want error", g, h, m, k)
+	}
+	if _, err := DiscreteLog(g, h, new(Stein)); err == nil {
+		t.Error("DiscreteLog modulo zero succeeded, want error")
+	}
+	large := New(new(big.Int).Lsh(big.NewInt(1), 20), big.NewInt(1))
+	if _, err := DiscreteLog(g, h, large); err == nil {
+		t.Errorf("DiscreteLog modulo %v succeeded, want error", large)
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	f := func(g *Stein, a, b int8) bool {
+		// t.Logf("g = %v, a = %v, b = %v", g, a, b)
+		n := New(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		z := new(Stein)
+		inv := z.ModInverse(g, n)
+		if n.Quad().Sign() == 0 || !new(Stein).GCD(nil, nil, g, n).Equals(one) {
+			return inv == nil && z.Equals(new(Stein))
+		}
+		if inv != z {
+			return false
+		}
+		d := new(Stein).Mul(g, inv)
+		return n.Divides(d.Sub(d, one))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSolveCongruence(t *testing.T) {
+	f := func(x, y *Stein, c, d int8) bool {
+		// t.Logf("x = %v, y = %v, c = %v, d = %v", x, y, c, d)
+		n := New(big.NewInt(int64(c)), big.NewInt(int64(d)))
+		if n.Quad().Sign() == 0 {
+			_, err := SolveCongruence(x, y, n)
+			return err != nil
+		}
+		// Take b = a·y so that a solution exists.
+		b := new(Stein).Mul(x, y)
+		sol, err := SolveCongruence(x, b, n)
+		if err != nil {
+			return false
+		}
+		r := new(Stein).Mul(x, sol)
+		return n.Divides(r.Sub(r, b))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSolveCongruenceNoSolution(t *testing.T) {
+	// Since 2 divides 2x and 6 but not 1, 2x = 1 modulo 6 has no solution.
+	a, b, n := New(big.NewInt(2), big.NewInt(0)), New(big.NewInt(1), big.NewInt(0)), New(big.NewInt(6), big.NewInt(0))
+	if x, err := SolveCongruence(a, b, n); err == nil {
+		t.Errorf("SolveCongruence(%v, %v, %v) = %v, want error", a, b, n, x)
+	}
+	// 2x = 4 modulo 6 has a solution, unique modulo 3.
+	b = New(big.NewInt(4), big.NewInt(0))
+	x, err := SolveCongruence(a, b, n)
+	if err != nil || !x.Equals(New(big.NewInt(2), big.NewInt(0))) {
+		t.Errorf("SolveCongruence(%v, %v, %v) = %v, %v, want (2+0ω)", a, b, n, x, err)
+	}
+}
+
+func TestQuotientRingSize(t *testing.T) {
+	for _, z := range SteinsUpToNorm(30)[1:] {
+		residues, _ := z.Residues()
+		size, err := z.QuotientRingSize()
+		if err != nil || size.Int64() != int64(len(residues)) {
+			t.Errorf("%v.QuotientRingSize() = %v, %v, want %d", z, size, err, len(residues))
+		}
+		m := z.Matrix()
+		det := new(big.Int).Mul(m[0][0], m[1][1])
+		det.Sub(det, new(big.Int).Mul(m[0][1], m[1][0]))
+		if det.Abs(det).Cmp(size) != 0 {
+			t.Errorf("%v.QuotientRingSize() = %v, want |det| = %v", z, size, det)
+		}
+	}
+	if size, err := Omega().QuotientRingSize(); err != nil || size.Int64() != 1 {
+		t.Errorf("Omega().QuotientRingSize() = %v, %v, want 1", size, err)
+	}
+	if _, err := new(Stein).QuotientRingSize(); err == nil {
+		t.Error("QuotientRingSize of zero succeeded, want error")
+	}
+}
+
+func TestCRT(t *testing.T) {
+	moduli := []*Stein{
+		New(big.NewInt(2), big.NewInt(1)), // 1-ω, over 3
+		New(big.NewInt(2), big.NewInt(0)), // inert
+		New(big.NewInt(3), big.NewInt(1)), // over 7
+		New(big.NewInt(3), big.NewInt(2)), // over 7, conjugate
+		New(big.NewInt(5), big.NewInt(0)), // inert
+	}
+	prod := Product(moduli...)
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		residues := make([]*Stein, len(moduli))
+		for i, m := range moduli {
+			residues[i] = new(Stein).Rem(x, m)
+		}
+		got, err := CRT(residues, moduli)
+		if err != nil {
+			return false
+		}
+		// The solution is unique modulo the product, and reduced.
+		return prod.Divides(new(Stein).Sub(got, x)) && got.Equals(new(Stein).reduce(x, prod))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCRTErrors(t *testing.T) {
+	one, two := New(big.NewInt(1), big.NewInt(0)), New(big.NewInt(2), big.NewInt(0))
+	if _, err := CRT([]*Stein{one}, []*Stein{two, two}); err == nil {
+		t.Error("CRT with mismatched lengths succeeded, want error")
+	}
+	if _, err := CRT([]*Stein{one, one}, []*Stein{two, New(big.NewInt(4), big.NewInt(2))}); err == nil {
+		t.Error("CRT with moduli 2 and 4+2ω succeeded, want error")
+	}
+	if _, err := CRT([]*Stein{one}, []*Stein{new(Stein)}); err == nil {
+		t.Error("CRT modulo zero succeeded, want error")
+	}
+	if x, err := CRT(nil, nil); err != nil || !x.Equals(new(Stein)) {
+		t.Errorf("CRT(nil, nil) = %v, %v, want zero", x, err)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import "math/big"
+
+// SqrtFloor returns an Eisenstein integer r near the square root of z,
+// together with the residual z - Mul(r, r). The quadrance of the residual is
+// a local minimum: no r + u, for u one of the six units, gives a smaller
+// one. It is not guaranteed to be the least over all Eisenstein integers.
+//
+// The search starts from the nearest lattice point to the principal complex
+// square root of z, and moves to a neighboring lattice point while that
+// lowers the quadrance of the residual.
+func (z *Stein) SqrtFloor() (*Stein, *Stein) {
+	prec := uint(z.l.BitLen())
+	if n := uint(z.r.BitLen()); n > prec {
+		prec = n
+	}
+	prec += 64
+	x, y := z.rect(prec)
+	// The principal square root of x+iy is u+iv, where u = √((m+x)/2) and
+	// v = ±√((m-x)/2) has the sign of y, and m is the modulus of x+iy.
+	m := new(big.Float).SetPrec(prec).SetInt(z.Quad())
+	m.Sqrt(m)
+	u := new(big.Float).SetPrec(prec).Add(m, x)
+	u.SetMantExp(u, -1)
+	u.Sqrt(u)
+	v := new(big.Float).SetPrec(prec).Sub(m, x)
+	v.SetMantExp(v, -1)
+	if v.Sign() > 0 {
+		v.Sqrt(v)
+	}
+	if y.Sign() < 0 {
+		v.Neg(v)
+	}
+	// In the ω basis, u+iv is a+bω with b = 2v/√3 and a = u + b/2.
+	s3 := new(big.Float).SetPrec(prec).SetInt64(3)
+	s3.Sqrt(s3)
+	b := new(big.Float).SetPrec(prec).Quo(v, s3)
+	a := new(big.Float).SetPrec(prec).Add(u, b)
+	b.SetMantExp(b, 1)
+	ra, _ := a.Rat(nil)
+	rb, _ := b.Rat(nil)
+	r := RoundToStein(ra, rb)
+	res := new(Stein).Mul(r, r)
+	res.Sub(z, res)
+	best := res.Quad()
+	next, nextRes := new(Stein), new(Stein)
+	for improved := true; improved; {
+		improved = false
+		for _, u := range units {
+			next.Add(r, u)
+			nextRes.Mul(next, next)
+			nextRes.Sub(z, nextRes)
+			if quad := nextRes.Quad(); quad.Cmp(best) < 0 {
+				r.Set(next)
+				res.Set(nextRes)
+				best = quad
+				improved = true
+			}
+		}
+	}
+	return r, res
+}
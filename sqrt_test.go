@@ -0,0 +1,60 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestSqrtFloorSquare(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		x.l.Lsh(&x.l, 40)
+		sq := new(Stein).Mul(x, x)
+		r, res := sq.SqrtFloor()
+		return res.Quad().Sign() == 0 && r.Quad().Cmp(x.Quad()) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSqrtFloorResidual(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		r, res := x.SqrtFloor()
+		check := new(Stein).Mul(r, r)
+		if !check.Add(check, res).Equals(x) {
+			return false
+		}
+		a, b, c, d, e, g := New(big.NewInt(1), big.NewInt(0)).Associates()
+		for _, u := range []*Stein{a, b, c, d, e, g} {
+			n := new(Stein).Add(r, u)
+			n.Mul(n, n)
+			if n.Sub(x, n).Quad().Cmp(res.Quad()) < 0 {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSqrtFloorBruteForce(t *testing.T) {
+	candidates := SteinsUpToNorm(100)
+	for _, z := range SteinsUpToNorm(300) {
+		_, res := z.SqrtFloor()
+		for _, c := range candidates {
+			d := new(Stein).Mul(c, c)
+			if d.Sub(z, d).Quad().Cmp(res.Quad()) < 0 {
+				t.Errorf("%v.SqrtFloor() residual %v, but %v is closer", z, res, c)
+				break
+			}
+		}
+	}
+}
@@ -4,10 +4,12 @@
 package eisen
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -24,16 +26,101 @@ func Omega() *Stein {
 	}
 }
 
+// omegaPowers holds the distinct powers 1, ω, and Mul(ω, ω) of ω.
+var omegaPowers = [3]*Stein{
+	New(big.NewInt(1), big.NewInt(0)),
+	New(big.NewInt(0), big.NewInt(1)),
+	New(big.NewInt(-1), big.NewInt(-1)),
+}
+
+// UnitPow returns a pointer to the Stein value ω raised to the power n.
+// Since ω has order three, only n mod 3 matters, and n may be negative.
+func UnitPow(n int) *Stein {
+	k := n % 3
+	if k < 0 {
+		k += 3
+	}
+	return new(Stein).Set(omegaPowers[k])
+}
+
 // Integers returns the pointers to the two integer components of z.
 func (z *Stein) Integers() (*big.Int, *big.Int) {
 	return &z.l, &z.r
 }
 
+// Signs returns the signs of the two integer components of z. Each sign is
+// -1, 0, or +1, as in big.Int.Sign.
+func (z *Stein) Signs() (int, int) {
+	return z.l.Sign(), z.r.Sign()
+}
+
 // String returns the string version of a Stein value.
 //
 // If z corresponds to a + bω, then the string is "(a+bω)", similar to
 // complex128 values.
 func (z *Stein) String() string {
+	return z.format("ω")
+}
+
+// Append appends the string version of z, as given by String, to b and
+// returns the extended buffer, as big.Int.Append does.
+func (z *Stein) Append(b []byte) []byte {
+	b = append(b, '(')
+	b = z.l.Append(b, 10)
+	if z.r.Sign() >= 0 {
+		b = append(b, '+')
+	}
+	b = z.r.Append(b, 10)
+	return append(b, "ω)"...)
+}
+
+// StringASCII returns the string version of a Stein value using only ASCII
+// characters.
+//
+// If z corresponds to a + bω, then the string is "(a+bw)", with the letter w
+// in place of ω.
+func (z *Stein) StringASCII() string {
+	return z.format("w")
+}
+
+// StringCartesian returns the string version of z in rectangular
+// coordinates.
+//
+// If z corresponds to x + yi as a complex number, then the string is
+// "(x+yi)", formatted like a complex128 value.
+func (z *Stein) StringCartesian() string {
+	return z.FormatCartesian(-1)
+}
+
+// FormatCartesian returns the string version of z in rectangular
+// coordinates, like StringCartesian. Both coordinates are formatted as by
+// strconv.FormatFloat with format 'g' and precision prec, so prec -1 gives
+// the shortest representation that identifies each float64 coordinate.
+func (z *Stein) FormatCartesian(prec int) string {
+	x, y := z.Float64()
+	im := strconv.FormatFloat(y, 'g', prec, 64)
+	if im[0] != '-' && im[0] != '+' {
+		im = "+" + im
+	}
+	return "(" + strconv.FormatFloat(x, 'g', prec, 64) + im + "i)"
+}
+
+// Float64 returns the float64 values nearest to the rectangular coordinates
+// of z. If z = a+bω, these are the real part a - b/2 and the imaginary part
+// b√3/2. A coordinate too large for a float64 is returned as ±Inf.
+func (z *Stein) Float64() (real, imag float64) {
+	prec := uint(z.l.BitLen())
+	if n := uint(z.r.BitLen()); n > prec {
+		prec = n
+	}
+	x, y := z.rect(prec + 64)
+	real, _ = x.Float64()
+	imag, _ = y.Float64()
+	return
+}
+
+// format returns the string "(a+bω)" for z, with omega in place of ω.
+func (z *Stein) format(omega string) string {
 	a := make([]string, 5)
 	a[0] = "("
 	a[1] = fmt.Sprintf("%v", &z.l)
@@ -42,7 +129,7 @@ func (z *Stein) String() string {
 	} else {
 		a[2] = fmt.Sprintf("+%v", &z.r)
 	}
-	a[3] = "ω"
+	a[3] = omega
 	a[4] = ")"
 	return strings.Join(a, "")
 }
@@ -55,6 +142,15 @@ func (z *Stein) Equals(y *Stein) bool {
 	return true
 }
 
+// cmp compares x and y lexicographically by their components, and returns
+// -1, 0, or +1.
+func cmp(x, y *Stein) int {
+	if c := x.l.Cmp(&y.l); c != 0 {
+		return c
+	}
+	return x.r.Cmp(&y.r)
+}
+
 // Set sets z equal to y, and returns z.
 func (z *Stein) Set(y *Stein) *Stein {
 	z.l.Set(&y.l)
@@ -62,6 +158,13 @@ func (z *Stein) Set(y *Stein) *Stein {
 	return z
 }
 
+// SetInt64s sets z equal to a+bω, and returns z.
+func (z *Stein) SetInt64s(a, b int64) *Stein {
+	z.l.SetInt64(a)
+	z.r.SetInt64(b)
+	return z
+}
+
 // New returns a pointer to the Stein value a+bω.
 func New(a, b *big.Int) *Stein {
 	z := new(Stein)
@@ -72,6 +175,12 @@ func New(a, b *big.Int) *Stein {
 
 // Scal sets z equal to y scaled by a, and returns z.
 func (z *Stein) Scal(y *Stein, a *big.Int) *Stein {
+	if a == &z.l || a == &z.r {
+		// The scalar would change after the first component is set.
+		t := getInt().Set(a)
+		defer putInt(t)
+		a = t
+	}
 	z.l.Mul(&y.l, a)
 	z.r.Mul(&y.r, a)
 	return z
@@ -105,29 +214,106 @@ func (z *Stein) Sub(x, y *Stein) *Stein {
 	return z
 }
 
+// AddInt sets z equal to the sum of y and the rational integer n, and
+// returns z.
+func (z *Stein) AddInt(y *Stein, n *big.Int) *Stein {
+	z.l.Add(&y.l, n)
+	z.r.Set(&y.r)
+	return z
+}
+
+// SubInt sets z equal to the difference of y and the rational integer n, and
+// returns z.
+func (z *Stein) SubInt(y *Stein, n *big.Int) *Stein {
+	z.l.Sub(&y.l, n)
+	z.r.Set(&y.r)
+	return z
+}
+
+// MulInt sets z equal to the product of y and the rational integer n, and
+// returns z. It is the same as Scal.
+func (z *Stein) MulInt(y *Stein, n *big.Int) *Stein {
+	return z.Scal(y, n)
+}
+
+// ScalQuoRem sets z equal to y divided by the rational integer a, with each
+// component truncated toward zero as in big.Int.QuoRem, and returns z
+// together with the remainder y - Scal(z, a). Each component of the
+// remainder has the sign of the matching component of y and is smaller than
+// a in absolute value. If a is zero, a division-by-zero run-time panic
+// occurs.
+func (z *Stein) ScalQuoRem(y *Stein, a *big.Int) (*Stein, *Stein) {
+	if a == &z.l || a == &z.r {
+		t := getInt().Set(a)
+		defer putInt(t)
+		a = t
+	}
+	r := new(Stein)
+	z.l.QuoRem(&y.l, a, &r.l)
+	z.r.QuoRem(&y.r, a, &r.r)
+	return z, r
+}
+
+// ModInt sets z equal to y reduced modulo the rational integer n, and
+// returns z. Each component is reduced as in big.Int.Mod into the range
+// [0, |n|), which gives the same canonical representative of y in ℤ[ω]/(n)
+// as reducing by the ideal in general, at the cost of two integer divisions.
+// If n is zero, a division-by-zero run-time panic occurs.
+func (z *Stein) ModInt(y *Stein, n *big.Int) *Stein {
+	if n == &z.l || n == &z.r {
+		t := getInt().Set(n)
+		defer putInt(t)
+		n = t
+	}
+	z.l.Mod(&y.l, n)
+	z.r.Mod(&y.r, n)
+	return z
+}
+
+// Content returns the greatest common divisor of the two integer components
+// of z, which is the largest rational integer that divides z. It is
+// non-negative, and zero only if z is zero.
+func (z *Stein) Content() *big.Int {
+	return new(big.Int).GCD(nil, nil, new(big.Int).Abs(&z.l), new(big.Int).Abs(&z.r))
+}
+
+// Primitive sets z equal to y divided by its content, and returns z. The
+// result has content one, unless y is zero, in which case z is set to zero.
+func (z *Stein) Primitive(y *Stein) *Stein {
+	c := y.Content()
+	if c.Sign() == 0 {
+		return z.Set(y)
+	}
+	z.l.Quo(&y.l, c)
+	z.r.Quo(&y.r, c)
+	return z
+}
+
 // Mul sets z equal to the product of x and y, and returns z.
 //
 // The multiplication rule is:
 // 		Mul(ω, ω) + ω + 1 = 0
 // This binary operation is commutative and associative.
 func (z *Stein) Mul(x, y *Stein) *Stein {
-	a := new(big.Int).Set(&x.l)
-	b := new(big.Int).Set(&x.r)
-	c := new(big.Int).Set(&y.l)
-	d := new(big.Int).Set(&y.r)
-	temp := new(big.Int)
-	z.l.Sub(
-		z.l.Mul(a, c),
-		temp.Mul(d, b),
-	)
-	z.r.Add(
-		z.r.Mul(d, a),
-		temp.Mul(b, c),
-	)
-	z.r.Sub(
-		&z.r,
-		temp.Mul(b, d),
-	)
+	mul(&z.l, &z.r, &x.l, &x.r, &y.l, &y.r)
+	return z
+}
+
+// MulOmega sets z equal to the product of y and ω, and returns z. This is a
+// rotation of y by 120 degrees and is cheaper than Mul(y, Omega()).
+func (z *Stein) MulOmega(y *Stein) *Stein {
+	a := new(big.Int).Set(&y.l)
+	z.l.Neg(&y.r)
+	z.r.Sub(a, &y.r)
+	return z
+}
+
+// MulOmegaSq sets z equal to the product of y and Mul(ω, ω), and returns z.
+// This is a rotation of y by 240 degrees.
+func (z *Stein) MulOmegaSq(y *Stein) *Stein {
+	a := new(big.Int).Set(&y.l)
+	z.l.Sub(&y.r, &y.l)
+	z.r.Neg(a)
 	return z
 }
 
@@ -136,48 +322,419 @@ func (z *Stein) Mul(x, y *Stein) *Stein {
 // 		Mul(a, a) + Mul(b, b) - Mul(a, b)
 // This is always non-negative.
 func (z *Stein) Quad() *big.Int {
+	// Mul(a, a) + Mul(b, b-a) needs only two multiplications.
 	quad := new(big.Int)
-	temp := new(big.Int)
-	quad.Add(
-		quad.Mul(&z.l, &z.l),
-		temp.Mul(&z.r, &z.r),
-	)
-	quad.Sub(
-		quad,
-		temp.Mul(&z.l, &z.r),
-	)
+	diff, prod := getInt(), getInt()
+	diff.Sub(&z.r, &z.l)
+	prod.Mul(&z.r, diff)
+	quad.Mul(&z.l, &z.l)
+	quad.Add(quad, prod)
+	putInt(diff, prod)
 	return quad
 }
 
+// Trace returns the trace of z, the sum of z and its conjugate. If z = a+bω,
+// then the trace is 2a - b.
+func (z *Stein) Trace() *big.Int {
+	trace := new(big.Int).Lsh(&z.l, 1)
+	return trace.Sub(trace, &z.r)
+}
+
+// CharPoly returns the coefficients of the characteristic polynomial
+//		X² - trace·X + quad
+// of z over the rationals, where trace and quad are the trace and the
+// quadrance of z. Since z satisfies it, it is the minimal polynomial of z
+// unless z is a rational integer.
+func (z *Stein) CharPoly() (trace, quad *big.Int) {
+	return z.Trace(), z.Quad()
+}
+
 // Quo sets z equal to the quotient of x and y, and returns z. Note that
 // truncated division is used.
 func (z *Stein) Quo(x, y *Stein) *Stein {
 	quad := y.Quad()
-	z.Conj(y)
-	z.Mul(x, z)
-	z.l.Quo(&z.l, quad)
-	z.r.Quo(&z.r, quad)
+	c, d := getInt(), getInt()
+	c.Sub(&y.l, &y.r)
+	d.Neg(&y.r)
+	mul(c, d, &x.l, &x.r, c, d)
+	z.l.Quo(c, quad)
+	z.r.Quo(d, quad)
+	putInt(c, d)
+	return z
+}
+
+// QuoRem sets z equal to the quotient of x and y, and r equal to the
+// remainder x - Mul(z, y), and returns the pair (z, r). Unlike Quo, the
+// quotient is rounded to the nearest Eisenstein integer as in RoundToStein,
+// so the quadrance of r is at most a third of the quadrance of y. This is
+// the Euclidean division of the Eisenstein integers.
+func (z *Stein) QuoRem(x, y, r *Stein) (*Stein, *Stein) {
+	quad := y.Quad()
+	c, d := getInt(), getInt()
+	c.Sub(&y.l, &y.r)
+	d.Neg(&y.r)
+	mul(c, d, &x.l, &x.r, c, d)
+	q := new(Stein)
+	roundQuo(&q.l, &q.r, c, d, quad)
+	putInt(c, d)
+	rem := new(Stein).Mul(q, y)
+	r.Sub(x, rem)
+	return z.Set(q), r
+}
+
+// Rem sets z equal to the remainder of the Euclidean division of x by y, as
+// in QuoRem, and returns z.
+func (z *Stein) Rem(x, y *Stein) *Stein {
+	_, r := new(Stein).QuoRem(x, y, z)
+	return r
+}
+
+// QuoError sets z equal to the quotient of x and y, rounded as in QuoRem,
+// and returns z together with the quadrance of the remainder. The quadrance
+// measures how far x/y is from the nearest Eisenstein integer; it is at most
+// a third of the quadrance of y, and zero exactly when y divides x.
+func (z *Stein) QuoError(x, y *Stein) (*Stein, *big.Int) {
+	_, r := z.QuoRem(x, y, new(Stein))
+	return z, r.Quad()
+}
+
+// DivMod sets z equal to the quotient of x and y, and m equal to the
+// remainder x - Mul(z, y), and returns the pair (z, m). Unlike QuoRem, the
+// remainder depends only on the residue class of x modulo y, as in
+// big.Int.DivMod: if y = a+bω has quadrance N and c = gcd(a, b), then
+// m = u+vω with 0 <= u < N/c and 0 <= v < c, the domain enumerated by
+// Residues. If y is zero, a division-by-zero run-time panic occurs.
+func (z *Stein) DivMod(x, y, m *Stein) (*Stein, *Stein) {
+	if y.l.Sign() == 0 && y.r.Sign() == 0 {
+		panic("eisen: division by zero")
+	}
+	rem := new(Stein).reduce(x, y)
+	q := new(Stein).Sub(x, rem)
+	q.Quo(q, y)
+	return z.Set(q), m.Set(rem)
+}
+
+// Divides returns true if z divides x, that is, if x is the product of z
+// and some Eisenstein integer. Zero divides only zero.
+func (z *Stein) Divides(x *Stein) bool {
+	quad := z.Quad()
+	if quad.Sign() == 0 {
+		return x.l.Sign() == 0 && x.r.Sign() == 0
+	}
+	c, d := getInt(), getInt()
+	defer putInt(c, d)
+	c.Sub(&z.l, &z.r)
+	d.Neg(&z.r)
+	mul(c, d, &x.l, &x.r, c, d)
+	return c.Rem(c, quad).Sign() == 0 && d.Rem(d, quad).Sign() == 0
+}
+
+// errInexact is returned when a division leaves a nonzero remainder.
+var errInexact = errors.New("eisen: division is not exact")
+
+// ExactQuo sets z equal to the quotient of x and y, and returns z, if y
+// divides x. Otherwise z is unchanged and ExactQuo returns an error, as it
+// does if y is zero. Unlike Quo, a quotient is never silently truncated.
+func (z *Stein) ExactQuo(x, y *Stein) (*Stein, error) {
+	quad := y.Quad()
+	if quad.Sign() == 0 {
+		return nil, errZero
+	}
+	c, d, rc, rd := getInt(), getInt(), getInt(), getInt()
+	defer putInt(c, d, rc, rd)
+	c.Sub(&y.l, &y.r)
+	d.Neg(&y.r)
+	mul(c, d, &x.l, &x.r, c, d)
+	c.QuoRem(c, quad, rc)
+	d.QuoRem(d, quad, rd)
+	if rc.Sign() != 0 || rd.Sign() != 0 {
+		return nil, errInexact
+	}
+	z.l.Set(c)
+	z.r.Set(d)
+	return z, nil
+}
+
+// RoundToStein returns a pointer to the Eisenstein integer nearest to the
+// point a+bω of ℚ(ω), with distance measured by the quadrance. The nearest
+// point is always a corner of the unit cell containing a+bω; on a tie the
+// first of the corners (⌊a⌋, ⌊b⌋), (⌊a⌋+1, ⌊b⌋), (⌊a⌋, ⌊b⌋+1), and
+// (⌊a⌋+1, ⌊b⌋+1) is chosen.
+func RoundToStein(a, b *big.Rat) *Stein {
+	p := new(big.Int).Mul(a.Num(), b.Denom())
+	q := new(big.Int).Mul(b.Num(), a.Denom())
+	n := new(big.Int).Mul(a.Denom(), b.Denom())
+	z := new(Stein)
+	roundQuo(&z.l, &z.r, p, q, n)
+	return z
+}
+
+// ScalRatRound sets z equal to the Eisenstein integer nearest to y scaled by
+// the rational r, rounded as in RoundToStein, and returns z. If r is an
+// integer, the result is exactly Scal(y, r).
+func (z *Stein) ScalRatRound(y *Stein, r *big.Rat) *Stein {
+	w := FromStein(y)
+	a, b := w.Rats()
+	a.Mul(a, r)
+	b.Mul(b, r)
+	return z.Set(RoundToStein(a, b))
+}
+
+// roundQuo sets zl+zrω equal to the Eisenstein integer nearest to
+// (p+qω)/n, where n is positive, using the tie-break rule of RoundToStein.
+func roundQuo(zl, zr, p, q, n *big.Int) {
+	fa, fb := getInt(), getInt()
+	r, s := getInt(), getInt()
+	defer putInt(fa, fb, r, s)
+	// The remainder of (p+qω) - n(fa+fbω) is r+sω, with 0 <= r, s < n.
+	fa.DivMod(p, n, r)
+	fb.DivMod(q, n, s)
+	// Moving from the corner r+sω to (r-n)+sω, r+(s-n)ω, or (r-n)+(s-n)ω
+	// changes the quadrance by n times n-2r+s, n-2s+r, or n-r-s, so the
+	// corners are compared without any multiplications.
+	d := [4]*big.Int{getInt().SetInt64(0), getInt(), getInt(), getInt()}
+	defer putInt(d[:]...)
+	d[1].Sub(n, r).Sub(d[1], r).Add(d[1], s)
+	d[2].Sub(n, s).Sub(d[2], s).Add(d[2], r)
+	d[3].Sub(n, r).Sub(d[3], s)
+	best := 0
+	for i := 1; i < len(d); i++ {
+		if d[i].Cmp(d[best]) < 0 {
+			best = i
+		}
+	}
+	zl.Add(fa, big.NewInt(int64(best&1)))
+	zr.Add(fb, big.NewInt(int64(best>>1)))
+}
+
+// Sum returns the sum of the Stein values in xs. The sum of no values is
+// zero.
+func Sum(xs ...*Stein) *Stein {
+	z := new(Stein)
+	for _, x := range xs {
+		z.Add(z, x)
+	}
+	return z
+}
+
+// Product returns the product of the Stein values in xs. The product of no
+// values is one.
+func Product(xs ...*Stein) *Stein {
+	z := New(big.NewInt(1), big.NewInt(0))
+	for _, x := range xs {
+		z.Mul(z, x)
+	}
 	return z
 }
 
 // Associates returns the six associates of z.
 func (z *Stein) Associates() (a, b, c, d, e, f *Stein) {
-	a.Set(z)
-	b.Neg(z)
-	unit := Omega()
-	c.Mul(z, unit)
-	unit.Neg(unit)
-	d.Mul(z, unit)
-	unit.Mul(unit, unit)
-	e.Mul(z, unit)
-	unit.Neg(unit)
-	f.Mul(z, unit)
+	a = new(Stein).Set(z)
+	b = new(Stein).Neg(z)
+	c = new(Stein).MulOmega(z)
+	d = new(Stein).Neg(c)
+	e = new(Stein).MulOmegaSq(z)
+	f = new(Stein).Neg(e)
 	return
 }
 
+// IsAssociate returns true if z and y are associates, that is, if z is the
+// product of y and one of the six units.
+func (z *Stein) IsAssociate(y *Stein) bool {
+	a, b, c, d, e, f := y.Associates()
+	for _, v := range []*Stein{a, b, c, d, e, f} {
+		if z.Equals(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormInvariantCheck returns true if the six associates of z, as given by
+// Associates and by Mul with each of the six units, all have the quadrance of
+// z. This always holds, since units have quadrance one, so the method serves
+// as a self-test of Associates, Mul, and Quad.
+func (z *Stein) NormInvariantCheck() bool {
+	quad := z.Quad()
+	a, b, c, d, e, f := z.Associates()
+	v := new(Stein)
+	for i, w := range []*Stein{a, b, c, d, e, f} {
+		if w.Quad().Cmp(quad) != 0 || v.Mul(units[i], z).Quad().Cmp(quad) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// UnitFactor returns the unit u such that z = Mul(u, y), and true, if z and y
+// are associates. Otherwise it returns nil and false. If z and y are both
+// zero, the unit returned is one.
+func (z *Stein) UnitFactor(y *Stein) (*Stein, bool) {
+	v := new(Stein)
+	for _, u := range units {
+		if v.Mul(u, y).Equals(z) {
+			return new(Stein).Set(u), true
+		}
+	}
+	return nil, false
+}
+
+// UnitInverse returns the multiplicative inverse of z and true, if z is a
+// unit. Otherwise it returns nil and false. The inverse of a unit is its
+// conjugate, since the product of the two is the quadrance one; for example,
+// the inverse of ω is ω².
+func (z *Stein) UnitInverse() (*Stein, bool) {
+	if z.Quad().Cmp(big.NewInt(1)) != 0 {
+		return nil, false
+	}
+	return new(Stein).Conj(z), true
+}
+
+// sextant sets z equal to the associate of y whose argument lies in the
+// half-open sextant [0°, 60°), that is, the associate a+bω with a > b >= 0,
+// and returns z together with the number k of 60° rotations, or
+// multiplications by 1+ω, that take y to z. If y is zero, then z is zero
+// and k is zero.
+func (z *Stein) sextant(y *Stein) (*Stein, int) {
+	z.Set(y)
+	if z.l.Sign() == 0 && z.r.Sign() == 0 {
+		return z, 0
+	}
+	t := new(big.Int)
+	for k := 0; ; k++ {
+		if z.r.Sign() >= 0 && z.l.Cmp(&z.r) > 0 {
+			return z, k
+		}
+		t.Set(&z.l)
+		z.l.Sub(&z.l, &z.r)
+		z.r.Set(t)
+	}
+}
+
+// Abs sets z equal to the canonical associate of y, and returns z. This is
+// the unique associate a+bω with a > b >= 0, whose argument lies in the
+// half-open sextant [0°, 60°): the ray at 0° is included and the ray at 60°
+// is not. The canonical associate of zero is zero.
+func (z *Stein) Abs(y *Stein) *Stein {
+	z.sextant(y)
+	return z
+}
+
+// ToSextant sets z equal to Abs(y), the associate of y in the sextant
+// [0°, 60°), and returns z together with the index k in 0..5 of the unit
+// applied, so that z = (1+ω)ᵏ·y. Then y is recovered as the product of z and
+// (1+ω)⁶⁻ᵏ, the inverse unit. If y is zero, then z is zero and k is zero.
+func (z *Stein) ToSextant(y *Stein) (*Stein, int) {
+	return z.sextant(y)
+}
+
+// units holds the six units in counterclockwise order, starting from one,
+// so that units[k] is the k-th power of the unit 1+ω.
+var units = [6]*Stein{
+	New(big.NewInt(1), big.NewInt(0)),
+	New(big.NewInt(1), big.NewInt(1)),
+	New(big.NewInt(0), big.NewInt(1)),
+	New(big.NewInt(-1), big.NewInt(0)),
+	New(big.NewInt(-1), big.NewInt(-1)),
+	New(big.NewInt(0), big.NewInt(-1)),
+}
+
+// rotate sets z equal to y rotated k times by 60°, that is, multiplied by
+// the k-th power of the unit 1+ω, and returns z.
+func (z *Stein) rotate(y *Stein, k int) *Stein {
+	k %= 6
+	if k < 0 {
+		k += 6
+	}
+	return z.Mul(y, units[k])
+}
+
+// Rotations returns the six rotations of z about the origin by 0°, 60°,
+// ..., 300°, in that order. They are the associates of z, ordered by angle;
+// the k-th one is the product of z and the k-th power of 1+ω.
+func (z *Stein) Rotations() [6]*Stein {
+	var r [6]*Stein
+	for k, u := range units {
+		r[k] = new(Stein).Mul(z, u)
+	}
+	return r
+}
+
+// CanonicalUnderD6 returns the least image of z, comparing components
+// lexicographically, under the dihedral group of order 12 that preserves the
+// lattice: the six rotations of z and the six rotations of its conjugate.
+// Two values have the same canonical form exactly when one is carried to the
+// other by a symmetry of the lattice that fixes the origin.
+func (z *Stein) CanonicalUnderD6() *Stein {
+	least := new(Stein).Set(z)
+	v := new(Stein)
+	for _, y := range []*Stein{z, new(Stein).Conj(z)} {
+		for _, u := range units {
+			if cmp(v.Mul(y, u), least) < 0 {
+				least.Set(v)
+			}
+		}
+	}
+	return least
+}
+
 // IsEisensteinPrime returns true if z is an Eisenstein prime.
+//
+// That is the case exactly when the quadrance of z is a rational prime, or
+// the square of a rational prime p = 2 mod 3. These rational primality tests
+// are exact for quadrances below 2⁶⁴; see ProbablyPrime for larger values.
 func (z *Stein) IsEisensteinPrime() bool {
-	return false
+	return z.ProbablyPrime(20)
+}
+
+// ProbablyPrime reports whether z is probably an Eisenstein prime, using
+// big.Int.ProbablyPrime(reps) for the underlying rational primality tests.
+// As with big.Int, the result is exact for quadrances below 2⁶⁴. For larger
+// quadrances it is probabilistic, and the probability that a composite z is
+// reported as prime is at most 4⁻ʳᵉᵖˢ.
+func (z *Stein) ProbablyPrime(reps int) bool {
+	quad := z.Quad()
+	if quad.ProbablyPrime(reps) {
+		return true
+	}
+	p := new(big.Int).Sqrt(quad)
+	if new(big.Int).Mul(p, p).Cmp(quad) != 0 {
+		return false
+	}
+	mod := new(big.Int).Mod(p, big.NewInt(3))
+	return mod.Int64() == 2 && p.ProbablyPrime(reps)
+}
+
+// NewRandom returns a pointer to a random Stein value whose components are
+// drawn independently from rnd, each with a magnitude below 2 to the power
+// bits and a random sign. If bits is not positive, the result is zero.
+func NewRandom(rnd *rand.Rand, bits int) *Stein {
+	z := new(Stein)
+	if bits <= 0 {
+		return z
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	z.l.Rand(rnd, limit)
+	z.r.Rand(rnd, limit)
+	if rnd.Intn(2) == 0 {
+		z.l.Neg(&z.l)
+	}
+	if rnd.Intn(2) == 0 {
+		z.r.Neg(&z.r)
+	}
+	return z
+}
+
+// Corpus returns n random Stein values drawn as in NewRandom from a source
+// seeded with seed. The same arguments always give the same values, so the
+// corpus can be shared by benchmarks and regression tests.
+func Corpus(seed int64, n int, bits int) []*Stein {
+	rnd := rand.New(rand.NewSource(seed))
+	corpus := make([]*Stein, n)
+	for i := range corpus {
+		corpus[i] = NewRandom(rnd, bits)
+	}
+	return corpus
 }
 
 // Generate a random Stein value for quick.Check testing.
@@ -4,7 +4,10 @@
 package eisen
 
 import (
+	"math"
 	"math/big"
+	"math/rand"
+	"strings"
 	"testing"
 	"testing/quick"
 )
@@ -232,6 +235,167 @@ func TestAddNegSub(t *testing.T) {
 	}
 }
 
+func TestTraceConj(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		sum := new(Stein).Add(x, new(Stein).Conj(x))
+		return sum.Equals(New(x.Trace(), new(big.Int)))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCharPolyRoot(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		trace, quad := x.CharPoly()
+		v := new(Stein).Mul(x, x)
+		v.Sub(v, new(Stein).Scal(x, trace))
+		v.AddInt(v, quad)
+		return v.Equals(new(Stein))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetInt64s(t *testing.T) {
+	want := New(big.NewInt(3), big.NewInt(-2))
+	if got := new(Stein).SetInt64s(3, -2); !got.Equals(want) {
+		t.Errorf("SetInt64s(3, -2) = %v, want %v", got, want)
+	}
+	f := func(x *Stein, a, b int64) bool {
+		// t.Logf("x = %v, a = %v, b = %v", x, a, b)
+		z := new(Stein).Set(x)
+		return z.SetInt64s(a, b) == z && z.Equals(New(big.NewInt(a), big.NewInt(b)))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAddIntMatchesAdd(t *testing.T) {
+	f := func(x *Stein, n int64) bool {
+		// t.Logf("x = %v, n = %v", x, n)
+		m := big.NewInt(n)
+		l, r := new(Stein), new(Stein)
+		l.AddInt(x, m)
+		r.Add(x, New(m, new(big.Int)))
+		return l.Equals(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSubIntMatchesSub(t *testing.T) {
+	f := func(x *Stein, n int64) bool {
+		// t.Logf("x = %v, n = %v", x, n)
+		m := big.NewInt(n)
+		l, r := new(Stein), new(Stein)
+		l.SubInt(x, m)
+		r.Sub(x, New(m, new(big.Int)))
+		return l.Equals(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMulIntMatchesScal(t *testing.T) {
+	f := func(x *Stein, n int64) bool {
+		// t.Logf("x = %v, n = %v", x, n)
+		m := big.NewInt(n)
+		l, r := new(Stein), new(Stein)
+		l.MulInt(x, m)
+		r.Scal(x, m)
+		return l.Equals(r) && l.Equals(new(Stein).Mul(x, New(m, new(big.Int))))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalQuoRem(t *testing.T) {
+	f := func(y *Stein, n int64) bool {
+		// t.Logf("y = %v, n = %v", y, n)
+		if n == 0 {
+			return true
+		}
+		a := big.NewInt(n)
+		q, r := new(Stein).ScalQuoRem(y, a)
+		l := new(Stein).Scal(q, a)
+		if !l.Add(l, r).Equals(y) {
+			return false
+		}
+		abs := new(big.Int).Abs(a)
+		rl, rr := r.Integers()
+		return new(big.Int).Abs(rl).Cmp(abs) < 0 && new(big.Int).Abs(rr).Cmp(abs) < 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModInt(t *testing.T) {
+	f := func(x *Stein, a int64) bool {
+		// t.Logf("x = %v, a = %v", x, a)
+		if a == 0 {
+			return true
+		}
+		n := big.NewInt(a)
+		z := new(Stein).ModInt(x, n)
+		p, q := z.Integers()
+		abs := new(big.Int).Abs(n)
+		if p.Sign() < 0 || p.Cmp(abs) >= 0 || q.Sign() < 0 || q.Cmp(abs) >= 0 {
+			return false
+		}
+		m := New(n, big.NewInt(0))
+		return z.CongruentTo(x, m) && z.Equals(new(Stein).reduce(x, m))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModIntAlias(t *testing.T) {
+	x := New(big.NewInt(7), big.NewInt(-5))
+	a, _ := x.Integers()
+	if x.ModInt(x, a); !x.Equals(New(big.NewInt(0), big.NewInt(2))) {
+		t.Errorf("ModInt with the modulus aliased = %v, want (0+2ω)", x)
+	}
+}
+
+func TestContentScal(t *testing.T) {
+	f := func(y *Stein, n int64) bool {
+		// t.Logf("y = %v, n = %v", y, n)
+		a := big.NewInt(n)
+		l := new(Stein).Scal(y, a).Content()
+		r := new(big.Int).Mul(y.Content(), a)
+		return l.Cmp(r.Abs(r)) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPrimitive(t *testing.T) {
+	f := func(y *Stein) bool {
+		// t.Logf("y = %v", y)
+		p := new(Stein).Primitive(y)
+		if y.Content().Sign() == 0 {
+			return p.Equals(y)
+		}
+		back := new(Stein).Scal(p, y.Content())
+		return p.Content().Cmp(big.NewInt(1)) == 0 && back.Equals(y) &&
+			new(Stein).Primitive(p).Equals(p)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestQuadPositive(t *testing.T) {
 	f := func(x *Stein) bool {
 		// t.Logf("x = %v", x)
@@ -241,3 +405,887 @@ func TestQuadPositive(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestSumLoop(t *testing.T) {
+	f := func(x, y, z *Stein) bool {
+		// t.Logf("x = %v, y = %v, z = %v", x, y, z)
+		l := Sum(x, y, z)
+		r := new(Stein)
+		for _, v := range []*Stein{x, y, z} {
+			r.Add(r, v)
+		}
+		return l.Equals(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSumEmpty(t *testing.T) {
+	if !Sum().Equals(new(Stein)) {
+		t.Errorf("Sum() = %v, want zero", Sum())
+	}
+}
+
+func TestProductEmpty(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	if !Product().Equals(one) {
+		t.Errorf("Product() = %v, want one", Product())
+	}
+}
+
+func TestProductFactorization(t *testing.T) {
+	// 21 = -ω² · (1-ω)² · (3+ω) · (2-ω)
+	unit := New(big.NewInt(1), big.NewInt(1))
+	lambda := New(big.NewInt(1), big.NewInt(-1))
+	pi := New(big.NewInt(3), big.NewInt(1))
+	piBar := new(Stein).Conj(pi)
+	got := Product(unit, lambda, lambda, pi, piBar)
+	want := New(big.NewInt(21), big.NewInt(0))
+	if !got.Equals(want) {
+		t.Errorf("Product = %v, want %v", got, want)
+	}
+}
+
+func TestMulOmega(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		l := new(Stein).MulOmega(x)
+		r := new(Stein).Mul(x, Omega())
+		return l.Equals(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMulOmegaSq(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		l := new(Stein).MulOmegaSq(x)
+		r := new(Stein).MulOmega(x)
+		r.MulOmega(r)
+		return l.Equals(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMulOmegaPeriod(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		l := new(Stein).Set(x)
+		for i := 0; i < 3; i++ {
+			l.MulOmega(l)
+		}
+		return l.Equals(x)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMulAlias(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		want := new(Stein).Mul(x, y)
+		l := new(Stein).Set(x)
+		l.Mul(l, y)
+		r := new(Stein).Set(y)
+		r.Mul(x, r)
+		s := new(Stein).Set(x)
+		s.Mul(s, s)
+		return l.Equals(want) && r.Equals(want) &&
+			s.Equals(new(Stein).Mul(x, x))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAlias(t *testing.T) {
+	binary := map[string]func(z, x, y *Stein) *Stein{
+		"Add": (*Stein).Add,
+		"Sub": (*Stein).Sub,
+		"Mul": (*Stein).Mul,
+		"Quo": (*Stein).Quo,
+	}
+	unary := map[string]func(z, y *Stein) *Stein{
+		"Neg":        (*Stein).Neg,
+		"Conj":       (*Stein).Conj,
+		"MulOmega":   (*Stein).MulOmega,
+		"MulOmegaSq": (*Stein).MulOmegaSq,
+		"Abs":        (*Stein).Abs,
+	}
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		for name, op := range binary {
+			if name == "Quo" && y.Quad().Sign() == 0 {
+				continue
+			}
+			want := op(new(Stein), x, y)
+			l := new(Stein).Set(x)
+			r := new(Stein).Set(y)
+			if !op(l, l, y).Equals(want) || !op(r, x, r).Equals(want) {
+				t.Errorf("%s with aliased receiver is wrong for %v, %v", name, x, y)
+				return false
+			}
+		}
+		for name, op := range unary {
+			want := op(new(Stein), x)
+			l := new(Stein).Set(x)
+			if !op(l, l).Equals(want) {
+				t.Errorf("%s with aliased receiver is wrong for %v", name, x)
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalAlias(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		a, b := x.Integers()
+		wantA := new(Stein).Scal(x, a)
+		wantB := new(Stein).Scal(x, b)
+		l, r := new(Stein).Set(x), new(Stein).Set(x)
+		la, _ := l.Integers()
+		_, rb := r.Integers()
+		if !l.Scal(l, la).Equals(wantA) || !r.Scal(r, rb).Equals(wantB) {
+			return false
+		}
+		if b.Sign() == 0 {
+			return true
+		}
+		q, m := new(Stein).ScalQuoRem(x, b)
+		z := new(Stein).Set(x)
+		_, zb := z.Integers()
+		zq, zm := z.ScalQuoRem(z, zb)
+		return zq.Equals(q) && zm.Equals(m)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func benchSteins(bits uint) (x, y *Stein) {
+	a := new(big.Int).Lsh(big.NewInt(1), bits)
+	x = New(new(big.Int).Sub(a, big.NewInt(3)), new(big.Int).Neg(a))
+	a.Rsh(a, 1)
+	y = New(new(big.Int).Add(a, big.NewInt(5)), new(big.Int).Sub(a, big.NewInt(7)))
+	return
+}
+
+func BenchmarkMul(b *testing.B) {
+	x, y := benchSteins(1000)
+	z := new(Stein)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		z.Mul(x, y)
+	}
+}
+
+// mulFourMul is the product computed directly from the multiplication rule,
+// with four multiplications. It is kept as a reference for BenchmarkMul.
+func mulFourMul(z, x, y *Stein) *Stein {
+	a, b := x.Integers()
+	c, d := y.Integers()
+	ac, bd := new(big.Int).Mul(a, c), new(big.Int).Mul(b, d)
+	ad, bc := new(big.Int).Mul(a, d), new(big.Int).Mul(b, c)
+	l := ac.Sub(ac, bd)
+	r := ad.Add(ad, bc)
+	return z.Set(New(l, r.Sub(r, bd)))
+}
+
+func BenchmarkMulFourMul(b *testing.B) {
+	x, y := benchSteins(1000)
+	z := new(Stein)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mulFourMul(z, x, y)
+	}
+}
+
+func TestMulMatchesFourMul(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		return new(Stein).Mul(x, y).Equals(mulFourMul(new(Stein), x, y))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMulQuoInverse(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		l := new(Stein).Mul(x, y)
+		l.Quo(l, y)
+		return l.Equals(x)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMulConcurrent(t *testing.T) {
+	x, y := benchSteins(300)
+	want := new(Stein).Mul(x, y)
+	done := make(chan bool)
+	for g := 0; g < 8; g++ {
+		go func() {
+			ok := true
+			z := new(Stein)
+			for i := 0; i < 1000; i++ {
+				if !z.Mul(x, y).Equals(want) || !z.Quo(z, y).Equals(x) {
+					ok = false
+				}
+			}
+			done <- ok
+		}()
+	}
+	for g := 0; g < 8; g++ {
+		if !<-done {
+			t.Error("concurrent Mul or Quo gave a wrong result")
+		}
+	}
+}
+
+func BenchmarkQuo(b *testing.B) {
+	x, y := benchSteins(1000)
+	x.Mul(x, y)
+	z := new(Stein)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		z.Quo(x, y)
+	}
+}
+
+func BenchmarkQuoRem(b *testing.B) {
+	x, y := benchSteins(1000)
+	x.Mul(x, y)
+	x.Add(x, New(big.NewInt(12345), big.NewInt(-678)))
+	z, r := new(Stein), new(Stein)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		z.QuoRem(x, y, r)
+	}
+}
+
+// roundQuoCorners is roundQuo with the quadrance of each corner of the unit
+// cell computed in full. It is kept as a reference for BenchmarkRoundQuo.
+func roundQuoCorners(zl, zr, p, q, n *big.Int) {
+	fa, fb := new(big.Int).Div(p, n), new(big.Int).Div(q, n)
+	r := new(big.Int).Sub(p, new(big.Int).Mul(n, fa))
+	s := new(big.Int).Sub(q, new(big.Int).Mul(n, fb))
+	rn, sn := new(big.Int).Sub(r, n), new(big.Int).Sub(s, n)
+	corners := [4]*Stein{New(r, s), New(rn, s), New(r, sn), New(rn, sn)}
+	best, least := 0, corners[0].Quad()
+	for i := 1; i < len(corners); i++ {
+		if quad := corners[i].Quad(); quad.Cmp(least) < 0 {
+			best, least = i, quad
+		}
+	}
+	zl.Add(fa, big.NewInt(int64(best&1)))
+	zr.Add(fb, big.NewInt(int64(best>>1)))
+}
+
+func TestRoundQuoMatchesCorners(t *testing.T) {
+	f := func(p, q int64, n uint16) bool {
+		// t.Logf("p = %v, q = %v, n = %v", p, q, n)
+		m := big.NewInt(int64(n) + 1)
+		a, b := big.NewInt(p), big.NewInt(q)
+		got, want := new(Stein), new(Stein)
+		roundQuo(&got.l, &got.r, a, b, m)
+		roundQuoCorners(&want.l, &want.r, a, b, m)
+		return got.Equals(want)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	// Ties between corners are broken the same way.
+	for p := int64(-6); p <= 6; p++ {
+		for q := int64(-6); q <= 6; q++ {
+			got, want := new(Stein), new(Stein)
+			roundQuo(&got.l, &got.r, big.NewInt(p), big.NewInt(q), big.NewInt(3))
+			roundQuoCorners(&want.l, &want.r, big.NewInt(p), big.NewInt(q), big.NewInt(3))
+			if !got.Equals(want) {
+				t.Errorf("roundQuo(%d, %d, 3) = %v, want %v", p, q, got, want)
+			}
+		}
+	}
+}
+
+func benchRoundQuo(b *testing.B, round func(zl, zr, p, q, n *big.Int)) {
+	x, y := benchSteins(1000)
+	p, q := new(big.Int).Mul(&x.l, &y.l), new(big.Int).Mul(&x.r, &y.l)
+	n := y.Quad()
+	z := new(Stein)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		round(&z.l, &z.r, p, q, n)
+	}
+}
+
+func BenchmarkRoundQuo(b *testing.B) {
+	benchRoundQuo(b, roundQuo)
+}
+
+func BenchmarkRoundQuoCorners(b *testing.B) {
+	benchRoundQuo(b, roundQuoCorners)
+}
+
+func BenchmarkQuad(b *testing.B) {
+	x, _ := benchSteins(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Quad()
+	}
+}
+
+// quadThreeMul is the quadrance computed directly from its definition, with
+// three multiplications. It is kept as a reference for BenchmarkQuad.
+func quadThreeMul(z *Stein) *big.Int {
+	a, b := z.Integers()
+	quad := new(big.Int).Mul(a, a)
+	quad.Add(quad, new(big.Int).Mul(b, b))
+	return quad.Sub(quad, new(big.Int).Mul(a, b))
+}
+
+func BenchmarkQuadThreeMul(b *testing.B) {
+	x, _ := benchSteins(1000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		quadThreeMul(x)
+	}
+}
+
+func TestQuadMatchesDefinition(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		return x.Quad().Cmp(quadThreeMul(x)) == 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAssociatesIsAssociate(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		a, b, c, d, e, g := x.Associates()
+		for _, v := range []*Stein{a, b, c, d, e, g} {
+			if !v.IsAssociate(x) || !x.IsAssociate(v) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNormInvariantCheck(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		return x.NormInvariantCheck()
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnitInverse(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	for _, u := range units {
+		inv, ok := u.UnitInverse()
+		if !ok || !new(Stein).Mul(u, inv).Equals(one) {
+			t.Errorf("%v.UnitInverse() = %v, %v, want the inverse", u, inv, ok)
+		}
+	}
+	if inv, _ := Omega().UnitInverse(); !inv.Equals(UnitPow(2)) {
+		t.Errorf("ω.UnitInverse() = %v, want %v", inv, UnitPow(2))
+	}
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		if x.Quad().Cmp(big.NewInt(1)) == 0 {
+			return true
+		}
+		inv, ok := x.UnitInverse()
+		return inv == nil && !ok
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	if inv, ok := new(Stein).UnitInverse(); inv != nil || ok {
+		t.Errorf("UnitInverse of zero = %v, %v, want nil, false", inv, ok)
+	}
+}
+
+func TestToSextant(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		z, k := new(Stein).ToSextant(x)
+		if k < 0 || k > 5 || !z.Equals(new(Stein).Abs(x)) {
+			return false
+		}
+		if !new(Stein).Mul(units[k], x).Equals(z) {
+			return false
+		}
+		return new(Stein).Mul(units[(6-k)%6], z).Equals(x)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestToSextantAssociates(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		if x.Quad().Sign() == 0 {
+			return true
+		}
+		want := new(Stein).Abs(x)
+		seen := make(map[int]bool)
+		for _, v := range x.Rotations() {
+			z, k := new(Stein).ToSextant(v)
+			if !z.Equals(want) || seen[k] {
+				return false
+			}
+			seen[k] = true
+		}
+		return len(seen) == 6
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnitFactor(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		if x.Quad().Sign() == 0 {
+			return true
+		}
+		for k := 0; k < 6; k++ {
+			want := new(Stein).rotate(New(big.NewInt(1), big.NewInt(0)), k)
+			z := new(Stein).rotate(x, k)
+			u, ok := z.UnitFactor(x)
+			if !ok || !u.Equals(want) {
+				return false
+			}
+		}
+		y := new(Stein).Add(x, x)
+		u, ok := y.UnitFactor(x)
+		return !ok && u == nil
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRotations(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		r := x.Rotations()
+		if !r[0].Equals(x) {
+			return false
+		}
+		// Two 60° turns make a 120° turn, which is MulOmega.
+		for k := range r {
+			next := new(Stein).Mul(r[k], New(big.NewInt(1), big.NewInt(1)))
+			if !r[(k+1)%6].Equals(next) ||
+				!r[(k+2)%6].Equals(new(Stein).MulOmega(r[k])) ||
+				r[k].Quad().Cmp(x.Quad()) != 0 || !r[k].IsAssociate(x) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCanonicalUnderD6(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		c := x.CanonicalUnderD6()
+		r, s := x.Rotations(), new(Stein).Conj(x).Rotations()
+		images := append(r[:], s[:]...)
+		found := false
+		for _, y := range images {
+			if !y.CanonicalUnderD6().Equals(c) || cmp(y, c) < 0 {
+				return false
+			}
+			found = found || y.Equals(c)
+		}
+		return found
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIsAssociateQuad(t *testing.T) {
+	f := func(x, y *Stein) bool {
+		// t.Logf("x = %v, y = %v", x, y)
+		if x.Quad().Cmp(y.Quad()) != 0 {
+			return !x.IsAssociate(y)
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	x := New(big.NewInt(3), big.NewInt(1))
+	if y := new(Stein).Scal(x, big.NewInt(2)); x.IsAssociate(y) {
+		t.Errorf("%v.IsAssociate(%v) = true, want false", x, y)
+	}
+	if y := new(Stein).Conj(x); x.IsAssociate(y) {
+		t.Errorf("%v.IsAssociate(%v) = true, want false", x, y)
+	}
+}
+
+// ratQuad returns the quadrance of (a+bω) - z.
+func ratQuad(a, b *big.Rat, z *Stein) *big.Rat {
+	d := NewRatein(a, b)
+	return d.Sub(d, FromStein(z)).Quad()
+}
+
+func TestRoundToSteinNearest(t *testing.T) {
+	f := func(x *Ratein) bool {
+		// t.Logf("x = %v", x)
+		a, b := x.Rats()
+		z := RoundToStein(a, b)
+		min := ratQuad(a, b, z)
+		fa := new(big.Int).Div(a.Num(), a.Denom())
+		fb := new(big.Int).Div(b.Num(), b.Denom())
+		for i := int64(-1); i <= 2; i++ {
+			for j := int64(-1); j <= 2; j++ {
+				w := New(
+					new(big.Int).Add(fa, big.NewInt(i)),
+					new(big.Int).Add(fb, big.NewInt(j)),
+				)
+				if ratQuad(a, b, w).Cmp(min) < 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRoundToStein(t *testing.T) {
+	var tests = []struct {
+		a, b *big.Rat
+		want *Stein
+	}{
+		{big.NewRat(0, 1), big.NewRat(0, 1), New(big.NewInt(0), big.NewInt(0))},
+		{big.NewRat(9, 20), big.NewRat(3, 5), New(big.NewInt(1), big.NewInt(1))},
+		{big.NewRat(-9, 20), big.NewRat(-3, 5), New(big.NewInt(-1), big.NewInt(-1))},
+		{big.NewRat(1, 10), big.NewRat(9, 10), New(big.NewInt(0), big.NewInt(1))},
+		{big.NewRat(2, 3), big.NewRat(1, 3), New(big.NewInt(0), big.NewInt(0))},
+		{big.NewRat(7, 2), big.NewRat(-5, 4), New(big.NewInt(4), big.NewInt(-1))},
+	}
+	for _, test := range tests {
+		if got := RoundToStein(test.a, test.b); !got.Equals(test.want) {
+			t.Errorf("RoundToStein(%v, %v) = %v, want %v",
+				test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestScalRatRoundInteger(t *testing.T) {
+	f := func(x *Stein, a int64) bool {
+		// t.Logf("x = %v, a = %v", x, a)
+		got := new(Stein).ScalRatRound(x, new(big.Rat).SetInt64(a))
+		return got.Equals(new(Stein).Scal(x, big.NewInt(a)))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalRatRoundHalf(t *testing.T) {
+	// Halving is off by a quadrance of at most 1/3, so doubling again is
+	// off by at most 4/3, which leaves a distance of at most one.
+	half, two := big.NewRat(1, 2), big.NewRat(2, 1)
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		z := new(Stein).ScalRatRound(x, half)
+		z.ScalRatRound(z, two)
+		return z.Dist(x).Cmp(big.NewInt(1)) <= 0
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnitPow(t *testing.T) {
+	one := New(big.NewInt(1), big.NewInt(0))
+	for k := -4; k <= 4; k++ {
+		if got := UnitPow(3 * k); !got.Equals(one) {
+			t.Errorf("UnitPow(%d) = %v, want %v", 3*k, got, one)
+		}
+	}
+	if got, want := UnitPow(-1), new(Stein).Mul(Omega(), Omega()); !got.Equals(want) {
+		t.Errorf("UnitPow(-1) = %v, want %v", got, want)
+	}
+	want := new(Stein).Set(one)
+	for n := 0; n < 10; n++ {
+		if got := UnitPow(n); !got.Equals(want) {
+			t.Errorf("UnitPow(%d) = %v, want %v", n, got, want)
+		}
+		want.Mul(want, Omega())
+	}
+}
+
+func TestSigns(t *testing.T) {
+	var tests = []struct {
+		a, b   int64
+		sa, sb int
+	}{
+		{0, 0, 0, 0},
+		{2, 1, 1, 1},
+		{1, 2, 1, 1},
+		{-1, 1, -1, 1},
+		{-2, -1, -1, -1},
+		{-1, -2, -1, -1},
+		{1, -1, 1, -1},
+		{3, 0, 1, 0},
+		{0, -3, 0, -1},
+	}
+	for _, test := range tests {
+		z := New(big.NewInt(test.a), big.NewInt(test.b))
+		if sa, sb := z.Signs(); sa != test.sa || sb != test.sb {
+			t.Errorf("%v.Signs() = (%d, %d), want (%d, %d)",
+				z, sa, sb, test.sa, test.sb)
+		}
+	}
+}
+
+func TestAbsAssociates(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		want := new(Stein).Abs(x)
+		a, b, c, d, e, g := x.Associates()
+		for _, v := range []*Stein{a, b, c, d, e, g} {
+			if !new(Stein).Abs(v).Equals(want) {
+				return false
+			}
+		}
+		return want.IsAssociate(x)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAbsIdempotent(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		l := new(Stein).Abs(x)
+		r := new(Stein).Abs(l)
+		return l.Equals(r)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAbsBoundary(t *testing.T) {
+	var tests = []struct {
+		y, want *Stein
+	}{
+		{new(Stein), new(Stein)},
+		{New(big.NewInt(-2), big.NewInt(0)), New(big.NewInt(2), big.NewInt(0))},
+		{New(big.NewInt(0), big.NewInt(3)), New(big.NewInt(3), big.NewInt(0))},
+		{New(big.NewInt(2), big.NewInt(2)), New(big.NewInt(2), big.NewInt(0))},
+		{New(big.NewInt(2), big.NewInt(1)), New(big.NewInt(2), big.NewInt(1))},
+		{New(big.NewInt(1), big.NewInt(2)), New(big.NewInt(2), big.NewInt(1))},
+	}
+	for _, test := range tests {
+		if got := new(Stein).Abs(test.y); !got.Equals(test.want) {
+			t.Errorf("Abs(%v) = %v, want %v", test.y, got, test.want)
+		}
+	}
+}
+
+func TestStringASCII(t *testing.T) {
+	var tests = []struct {
+		z    *Stein
+		want string
+	}{
+		{new(Stein), "(0+0w)"},
+		{New(big.NewInt(3), big.NewInt(-2)), "(3-2w)"},
+		{New(big.NewInt(-1), big.NewInt(5)), "(-1+5w)"},
+	}
+	for _, test := range tests {
+		if got := test.z.StringASCII(); got != test.want {
+			t.Errorf("StringASCII() = %q, want %q", got, test.want)
+		}
+	}
+}
+
+func TestStringASCIIMatchesString(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		return strings.Replace(x.StringASCII(), "w", "ω", 1) == x.String()
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	f := func(x *Stein) bool {
+		// t.Logf("x = %v", x)
+		prefix := []byte("x = ")
+		return string(x.Append(nil)) == x.String() &&
+			string(x.Append(prefix)) == "x = "+x.String()
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	if got := New(big.NewInt(-1), big.NewInt(-5)).Append(nil); string(got) != "(-1-5ω)" {
+		t.Errorf("Append(nil) = %q, want %q", got, "(-1-5ω)")
+	}
+}
+
+func BenchmarkAppend(b *testing.B) {
+	xs := Corpus(1, 100, 64)
+	var buf []byte
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf = buf[:0]
+		for _, x := range xs {
+			buf = x.Append(buf)
+		}
+	}
+}
+
+func BenchmarkStringConcat(b *testing.B) {
+	xs := Corpus(1, 100, 64)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := ""
+		for _, x := range xs {
+			s += x.String()
+		}
+	}
+}
+
+func TestFormatCartesian(t *testing.T) {
+	var tests = []struct {
+		z    *Stein
+		prec int
+		want string
+	}{
+		{Omega(), 3, "(-0.5+0.866i)"},
+		{new(Stein), -1, "(0+0i)"},
+		{New(big.NewInt(2), big.NewInt(0)), -1, "(2+0i)"},
+		{New(big.NewInt(1), big.NewInt(2)), 4, "(0+1.732i)"},
+		{New(big.NewInt(-1), big.NewInt(-1)), 3, "(-0.5-0.866i)"},
+	}
+	for _, test := range tests {
+		if got := test.z.FormatCartesian(test.prec); got != test.want {
+			t.Errorf("%v.FormatCartesian(%d) = %q, want %q",
+				test.z, test.prec, got, test.want)
+		}
+	}
+	if got, want := Omega().StringCartesian(), "(-0.5+0.8660254037844386i)"; got != want {
+		t.Errorf("Omega().StringCartesian() = %q, want %q", got, want)
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	f := func(a, b int32) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		x, y := New(big.NewInt(int64(a)), big.NewInt(int64(b))).Float64()
+		wantX := float64(a) - float64(b)/2
+		wantY := float64(b) * math.Sqrt(3) / 2
+		return x == wantX && math.Abs(y-wantY) <= 1e-15*math.Abs(wantY)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFloat64Overflow(t *testing.T) {
+	huge := new(big.Int).Lsh(big.NewInt(1), 1100)
+	x, y := New(huge, new(big.Int).Neg(huge)).Float64()
+	if !math.IsInf(x, 1) || !math.IsInf(y, -1) {
+		t.Errorf("Float64() = (%v, %v), want (+Inf, -Inf)", x, y)
+	}
+	x, y = New(huge, huge).Float64()
+	if !math.IsInf(x, 1) || !math.IsInf(y, 1) {
+		t.Errorf("Float64() = (%v, %v), want (+Inf, +Inf)", x, y)
+	}
+}
+
+func TestNewRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, bits := range []int{1, 8, 64, 1000} {
+		negative, long := false, false
+		for i := 0; i < 100; i++ {
+			z := NewRandom(rnd, bits)
+			a, b := z.Integers()
+			if a.BitLen() > bits || b.BitLen() > bits {
+				t.Fatalf("NewRandom(rnd, %d) = %v, want components below 2^%d", bits, z, bits)
+			}
+			if a.Sign() < 0 || b.Sign() < 0 {
+				negative = true
+			}
+			if a.BitLen() == bits || b.BitLen() == bits {
+				long = true
+			}
+		}
+		if !negative || !long {
+			t.Errorf("NewRandom(rnd, %d): negative = %v, full length = %v, want both", bits, negative, long)
+		}
+	}
+	if z := NewRandom(rnd, 0); !z.Equals(new(Stein)) {
+		t.Errorf("NewRandom(rnd, 0) = %v, want zero", z)
+	}
+}
+
+func TestCorpus(t *testing.T) {
+	x, y := Corpus(1, 100, 64), Corpus(1, 100, 64)
+	if len(x) != 100 || len(y) != 100 {
+		t.Fatalf("len(Corpus(1, 100, 64)) = %v, %v, want 100", len(x), len(y))
+	}
+	for i := range x {
+		if !x[i].Equals(y[i]) {
+			t.Errorf("Corpus(1, 100, 64)[%d] = %v and %v, want equal", i, x[i], y[i])
+		}
+	}
+	z := Corpus(2, 100, 64)
+	same := true
+	for i := range x {
+		if !x[i].Equals(z[i]) {
+			same = false
+		}
+	}
+	if same {
+		t.Errorf("Corpus(1, 100, 64) = Corpus(2, 100, 64), want different values")
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+// A SteinVector is a finite sequence of Stein values.
+type SteinVector []*Stein
+
+// Convolve returns the full linear convolution of a and b, whose k-th entry
+// is the sum of Mul(a[i], b[j]) over i + j = k. It has len(a) + len(b) - 1
+// entries, or none if a or b is empty. A single scratch value holds each
+// product, so the only allocations are for the result.
+func Convolve(a, b SteinVector) SteinVector {
+	if len(a) == 0 || len(b) == 0 {
+		return SteinVector{}
+	}
+	c := make(SteinVector, len(a)+len(b)-1)
+	for k := range c {
+		c[k] = new(Stein)
+	}
+	t := new(Stein)
+	for i, x := range a {
+		for j, y := range b {
+			t.Mul(x, y)
+			c[i+j].Add(c[i+j], t)
+		}
+	}
+	return c
+}
+
+// A SteinMatrix is a matrix of Stein values, stored as a slice of rows. All
+// rows of a matrix have the same length.
+type SteinMatrix []SteinVector
+
+// isRectangular returns true if all rows of m have the same length.
+func (m SteinMatrix) isRectangular() bool {
+	for _, row := range m {
+		if len(row) != len(m[0]) {
+			return false
+		}
+	}
+	return true
+}
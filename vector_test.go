@@ -0,0 +1,78 @@
+// Copyright (c) 2016 Melvin Eloy Irizarry-Gelpí
+// Licenced under the MIT License.
+
+package eisen
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+// convolveNaive is the convolution computed with a fresh value for every
+// product. It is kept as a reference for Convolve.
+func convolveNaive(a, b SteinVector) SteinVector {
+	if len(a) == 0 || len(b) == 0 {
+		return SteinVector{}
+	}
+	c := make(SteinVector, len(a)+len(b)-1)
+	for k := range c {
+		c[k] = new(Stein)
+	}
+	for i := range a {
+		for j := range b {
+			c[i+j] = new(Stein).Add(c[i+j], new(Stein).Mul(a[i], b[j]))
+		}
+	}
+	return c
+}
+
+func TestConvolve(t *testing.T) {
+	f := func(a, b []*Stein) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		got, want := Convolve(a, b), convolveNaive(a, b)
+		if len(got) != len(want) {
+			return false
+		}
+		for k := range got {
+			if !got[k].Equals(want[k]) {
+				return false
+			}
+		}
+		return len(a) == 0 || len(b) == 0 || len(got) == len(a)+len(b)-1
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConvolveMatchesPolyMul(t *testing.T) {
+	f := func(a, b []*Stein) bool {
+		// t.Logf("a = %v, b = %v", a, b)
+		return polyEqual(SteinPoly(Convolve(a, b)), SteinPoly(a).Mul(SteinPoly(b)))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func benchVectors(n int) (a, b SteinVector) {
+	return Corpus(1, n, 64), Corpus(2, n, 64)
+}
+
+func BenchmarkConvolve(b *testing.B) {
+	x, y := benchVectors(1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Convolve(x, y)
+	}
+}
+
+func BenchmarkConvolveNaive(b *testing.B) {
+	x, y := benchVectors(1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		convolveNaive(x, y)
+	}
+}